@@ -5,16 +5,36 @@ package main
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/carli/coviar-backend/internal/auth"
 	"github.com/carli/coviar-backend/internal/bodega"
+	"github.com/carli/coviar-backend/internal/certificado"
+	"github.com/carli/coviar-backend/internal/comparativa"
 	"github.com/carli/coviar-backend/internal/config"
+	"github.com/carli/coviar-backend/internal/directorio"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+	"github.com/carli/coviar-backend/internal/evidencia"
+	"github.com/carli/coviar-backend/internal/exportacion"
+	"github.com/carli/coviar-backend/internal/geo"
+	"github.com/carli/coviar-backend/internal/indicador"
+	"github.com/carli/coviar-backend/internal/inv"
+	"github.com/carli/coviar-backend/internal/membresia"
+	"github.com/carli/coviar-backend/internal/plan"
 	"github.com/carli/coviar-backend/internal/platform/database"
+	"github.com/carli/coviar-backend/internal/platform/email"
+	"github.com/carli/coviar-backend/internal/platform/storage"
+	"github.com/carli/coviar-backend/internal/puntaje"
+	"github.com/carli/coviar-backend/internal/reporte"
+	"github.com/carli/coviar-backend/internal/segmento"
 	"github.com/carli/coviar-backend/internal/usuario"
+	"github.com/carli/coviar-backend/internal/vigencia"
 
 	//godotenv para leer lo del .env soluciona error
 	"github.com/joho/godotenv"
@@ -35,8 +55,9 @@ func corsMiddleware(next http.Handler) http.Handler {
 
 		// Headers CORS
 		w.Header().Set("Access-Control-Allow-Origin", origin)
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, If-None-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 		w.Header().Set("Access-Control-Max-Age", "3600")
 
@@ -113,9 +134,34 @@ func main() {
 
 	// 4. Inicializar módulos
 
+	// Correo (invitaciones y recordatorios de vencimiento)
+	var mailer email.Mailer = email.Log{}
+	if cfg.SMTPUser != "" && cfg.SMTPPassword != "" {
+		mailer = email.NewSMTP(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword)
+	} else {
+		fmt.Println("⚠️  SMTP no configurado: las invitaciones y recordatorios solo se registran en el log")
+	}
+
+	// Módulo Membresía (usuarios de cada bodega, con su rol, e invitaciones)
+	membresiaRepo := membresia.NewRepository(db)
+	membresiaService := membresia.NewService(membresiaRepo, mailer, cfg.FrontendURL)
+	membresiaHandler := membresia.NewHandler(membresiaService)
+
+	// Módulo Geo (provincias, departamentos y distritos, incluidos en el binario)
+	geoService, err := geo.NewService()
+	if err != nil {
+		log.Fatal("❌ Error al cargar el catálogo geográfico:", err)
+	}
+	geoHandler := geo.NewHandler(geoService)
+
+	// Módulo Registro del INV (establecimientos inscriptos, importados por un administrador)
+	invRepo := inv.NewRepository(db)
+	invService := inv.NewService(invRepo)
+	invHandler := inv.NewHandler(invService)
+
 	// Módulo Bodega
 	bodegaRepo := bodega.NewRepository(db)
-	bodegaService := bodega.NewService(bodegaRepo)
+	bodegaService := bodega.NewService(bodegaRepo, membresiaService, geoService, invService)
 	bodegaHandler := bodega.NewHandler(bodegaService)
 
 	// Módulo Usuario
@@ -123,6 +169,114 @@ func main() {
 	usuarioService := usuario.NewService(usuarioRepo)
 	usuarioHandler := usuario.NewHandler(usuarioService)
 
+	// Módulo Puntaje (niveles de sostenibilidad)
+	puntajeRepo := puntaje.NewRepository(db)
+	puntajeService := puntaje.NewService(puntajeRepo)
+	puntajeHandler := puntaje.NewHandler(puntajeService)
+
+	// Módulo Segmento
+	segmentoRepo := segmento.NewRepository(db)
+	segmentoService := segmento.NewService(segmentoRepo)
+	segmentoHandler := segmento.NewHandler(segmentoService)
+
+	// Módulo Catálogo de Indicadores
+	indicadorRepo := indicador.NewRepository(db)
+	indicadorService := indicador.NewService(indicadorRepo, segmentoService)
+	indicadorHandler := indicador.NewHandler(indicadorService)
+
+	// Módulo Evaluación
+	evaluacionRepo := evaluacion.NewRepository(db)
+	evaluacionService := evaluacion.NewService(evaluacionRepo, puntajeService, segmentoService, indicadorService, usuarioService)
+	evaluacionHandler := evaluacion.NewHandler(evaluacionService)
+
+	// Módulo Evidencia (archivos adjuntos a las respuestas)
+	evidenciaStorage, err := storage.NewLocal(cfg.EvidenciasDir)
+	if err != nil {
+		log.Fatal("❌ Error al inicializar el almacenamiento de evidencias:", err)
+	}
+	evidenciaRepo := evidencia.NewRepository(db)
+	evidenciaService := evidencia.NewService(evidenciaRepo, evidenciaStorage, evaluacionService)
+	evidenciaHandler := evidencia.NewHandler(evidenciaService, membresiaService)
+
+	// Módulo Plan de Mejora
+	planRepo := plan.NewRepository(db)
+	planService := plan.NewService(planRepo, evaluacionService)
+	planHandler := plan.NewHandler(planService)
+
+	// Módulo Exportación (solo administradores)
+	exportacionRepo := exportacion.NewRepository(db)
+	exportacionService := exportacion.NewService(exportacionRepo, segmentoService)
+	exportacionHandler := exportacion.NewHandler(exportacionService)
+
+	// Módulo Certificado (certificados firmados con verificación pública)
+	certificadoFirmante, err := certificado.NewFirmante(cfg.CertificadoClave)
+	if err != nil {
+		log.Fatal("❌ Error al cargar la clave de firma de certificados:", err)
+	}
+	certificadoRepo := certificado.NewRepository(db)
+	certificadoService := certificado.NewService(certificadoRepo, certificadoFirmante, evaluacionService, bodegaService, puntajeService, cfg.PublicURL)
+	certificadoHandler := certificado.NewHandler(certificadoService)
+
+	// Al aprobarse una evaluación se emite su certificado. Si falla se puede emitir
+	// después a mano, por eso no se revierte la aprobación.
+	evaluacionService.AlAprobar(func(ev *domain.Evaluacion) {
+		if _, err := certificadoService.Emitir(ev.IdEvaluacion); err != nil {
+			log.Printf("⚠️  No se pudo emitir el certificado de la evaluación %d: %v", ev.IdEvaluacion, err)
+		}
+	})
+
+	// Módulo Reporte (informe PDF de una evaluación)
+	reporteService := reporte.NewService(evaluacionService, bodegaService, segmentoService, planService, certificadoService)
+	reporteHandler := reporte.NewHandler(reporteService)
+
+	// Módulo Comparativa (estadísticas anónimas de bodegas de la misma provincia y segmento)
+	comparativaRepo := comparativa.NewRepository(db)
+	comparativaService := comparativa.NewService(comparativaRepo, bodegaService, evaluacionService, segmentoService)
+	comparativaHandler := comparativa.NewHandler(comparativaService)
+
+	// Módulo Directorio (listado público de bodegas certificadas)
+	directorioRepo := directorio.NewRepository(db)
+	directorioService := directorio.NewService(directorioRepo, certificadoService)
+	directorioHandler := directorio.NewHandler(directorioService)
+
+	// Módulo Vigencia (vencimiento de evaluaciones aprobadas y recordatorios de renovación)
+	vigenciaRepo := vigencia.NewRepository(db)
+	vigenciaService := vigencia.NewService(vigenciaRepo, evaluacionService, bodegaService, mailer, cfg.FrontendURL)
+	go vigenciaService.Programar(24 * time.Hour)
+
+	// Resolución de la bodega de cada petición, para verificar la membresía de los
+	// usuarios con rol bodega
+	bodegaDeEvaluacion := func(id int) (int, error) {
+		ev, err := evaluacionService.GetByID(id)
+		if errors.Is(err, evaluacion.ErrEvaluacionNoEncontrada) {
+			return 0, membresia.ErrRecursoNoEncontrado
+		}
+		if err != nil {
+			return 0, err
+		}
+		return ev.IdBodega, nil
+	}
+	bodegaDeItemPlan := func(id int) (int, error) {
+		item, err := planService.GetItem(id)
+		if errors.Is(err, plan.ErrItemNoEncontrado) {
+			return 0, membresia.ErrRecursoNoEncontrado
+		}
+		if err != nil {
+			return 0, err
+		}
+		pl, err := planService.GetByID(item.IdPlan)
+		if err != nil {
+			return 0, err
+		}
+		return pl.IdBodega, nil
+	}
+	miembroDeBodega := membresiaService.RequireMiembro(membresia.DeConsulta("idBodega"))
+	miembroDeEvaluacion := membresiaService.RequireMiembro(membresia.Mediante(membresia.DeRuta("/api/evaluaciones/"), bodegaDeEvaluacion))
+
+	// Los auditores solo acceden a las evaluaciones que tienen asignadas y a las bodegas de esas evaluaciones
+	auditorDeEvaluacion := evaluacionHandler.RequireAuditorAsignado(membresia.DeRuta("/api/evaluaciones/"))
+	auditorDeBodega := evaluacionHandler.RequireAuditorDeBodega(membresia.DeConsulta("idBodega"))
+
 	// 5. Configurar rutas
 	mux := http.NewServeMux()
 
@@ -130,24 +284,40 @@ func main() {
 	mux.HandleFunc("/", homeHandler)
 	mux.HandleFunc("/health", healthHandler)
 
-	// Rutas de Bodega
-	mux.HandleFunc("/api/bodegas", func(w http.ResponseWriter, r *http.Request) {
+	// Rutas de Bodega (los usuarios con rol bodega solo acceden a las bodegas de las que
+	// son miembros; quien registra una bodega queda como propietario)
+	mux.Handle("/api/bodegas", auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
 			bodegaHandler.ListBodegas(w, r)
 		} else if r.Method == http.MethodPost {
-			bodegaHandler.CreateBodega(w, r)
+			auth.RequireRole("admin", "bodega")(http.HandlerFunc(bodegaHandler.CreateBodega)).ServeHTTP(w, r)
 		} else {
 			http.Error(w, "Método no permitido", http.StatusMethodNotAllowed)
 		}
-	})
-	mux.HandleFunc("/api/bodegas/", bodegaHandler.GetBodega)
+	})))
+	mux.Handle("/api/bodegas/", auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if membresia.EsRutaMembresia(r.URL.Path) {
+			membresiaHandler.Route(w, r)
+		} else if r.Method == http.MethodGet {
+			membresiaService.RequireMiembro(membresia.DeRuta("/api/bodegas/"))(http.HandlerFunc(bodegaHandler.Route)).ServeHTTP(w, r)
+		} else if r.Method == http.MethodPatch {
+			// Los editores cambian el perfil; el servicio reserva la razón social al propietario
+			auth.RequireRole("admin", "bodega")(membresiaService.RequireMiembro(membresia.DeRuta("/api/bodegas/"))(http.HandlerFunc(bodegaHandler.Route))).ServeHTTP(w, r)
+		} else {
+			auth.RequireRole("admin", "bodega")(membresiaService.RequireRolBodega(domain.RolBodegaPropietario, membresia.DeRuta("/api/bodegas/"))(http.HandlerFunc(bodegaHandler.Route))).ServeHTTP(w, r)
+		}
+	})))
+
+	// Rutas de Membresía
+	mux.Handle("/api/membresias", auth.AuthMiddleware(http.HandlerFunc(membresiaHandler.ListMembresias)))
+	mux.Handle("/api/invitaciones/aceptar", auth.AuthMiddleware(http.HandlerFunc(membresiaHandler.AceptarInvitacion)))
 
 	// Rutas de Usuario
 	mux.HandleFunc("/api/usuarios", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
 			usuarioHandler.ListAll(w, r)
 		} else if r.Method == http.MethodPost {
-			usuarioHandler.Register(w, r)
+			auth.AuthMiddleware(auth.RequireRole("admin")(http.HandlerFunc(usuarioHandler.Crear))).ServeHTTP(w, r)
 		} else {
 			http.Error(w, "Método no permitido", http.StatusMethodNotAllowed)
 		}
@@ -164,6 +334,99 @@ func main() {
 		}
 	})
 
+	// Rutas de Segmento
+	mux.HandleFunc("/api/segmentos", segmentoHandler.ListSegmentos)
+	mux.HandleFunc("/api/segmentos/", segmentoHandler.GetSegmento)
+
+	// Rutas de Niveles de Sostenibilidad
+	mux.HandleFunc("/api/niveles-sostenibilidad", puntajeHandler.ListNiveles)
+
+	// Rutas del Catálogo de Indicadores (borradores, publicación y retiro solo para admin)
+	mux.HandleFunc("/api/indicadores", indicadorHandler.GetCatalogoVigente)
+	mux.Handle("/api/catalogo/versiones", auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			indicadorHandler.ListVersiones(w, r)
+		} else if r.Method == http.MethodPost {
+			auth.RequireRole("admin")(http.HandlerFunc(indicadorHandler.CreateVersion)).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Método no permitido", http.StatusMethodNotAllowed)
+		}
+	})))
+	mux.Handle("/api/catalogo/versiones/", auth.AuthMiddleware(http.HandlerFunc(indicadorHandler.Route)))
+
+	// Rutas de Evaluación (requieren autenticación)
+	mux.Handle("/api/evaluaciones", auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			miembroDeBodega(http.HandlerFunc(evaluacionHandler.ListEvaluaciones)).ServeHTTP(w, r)
+		} else if r.Method == http.MethodPost {
+			auth.RequireRole("admin", "bodega")(membresiaService.RequireMiembro(membresia.DelCuerpo("idBodega"))(http.HandlerFunc(evaluacionHandler.CreateEvaluacion))).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Método no permitido", http.StatusMethodNotAllowed)
+		}
+	})))
+	mux.Handle("/api/evaluaciones/", auth.AuthMiddleware(miembroDeEvaluacion(auditorDeEvaluacion(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reporte.EsRutaReporte(r.URL.Path) {
+			reporteHandler.GetReportePDF(w, r)
+			return
+		}
+		evaluacionHandler.Route(w, r)
+	})))))
+
+	// Rutas del Auditor (evaluaciones asignadas para revisar)
+	mux.Handle("/api/auditor/evaluaciones", auth.AuthMiddleware(auth.RequireRole("auditor")(http.HandlerFunc(evaluacionHandler.ListAsignadas))))
+
+	// Rutas de Evidencias (solo la bodega, auditores y administradores)
+	mux.Handle("/api/evidencias", auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			evidenciaHandler.ListEvidencias(w, r)
+		} else if r.Method == http.MethodPost {
+			evidenciaHandler.UploadEvidencia(w, r)
+		} else {
+			http.Error(w, "Método no permitido", http.StatusMethodNotAllowed)
+		}
+	})))
+	mux.Handle("/api/evidencias/", auth.AuthMiddleware(http.HandlerFunc(evidenciaHandler.Route)))
+
+	// Rutas del Registro del INV (solo administradores)
+	mux.Handle("/api/admin/registro-inv", auth.AuthMiddleware(auth.RequireRole("admin")(http.HandlerFunc(invHandler.ImportarRegistro))))
+	mux.Handle("/api/admin/registro-inv/verificacion", auth.AuthMiddleware(auth.RequireRole("admin")(http.HandlerFunc(invHandler.Verificacion))))
+
+	// Rutas de Exportación (solo administradores)
+	mux.Handle("/api/admin/exportaciones/evaluaciones", auth.AuthMiddleware(auth.RequireRole("admin")(http.HandlerFunc(exportacionHandler.ExportEvaluaciones))))
+
+	// Rutas del Plan de Mejora (los auditores solo pueden consultarlo)
+	mux.Handle("/api/planes", auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			miembroDeBodega(auditorDeBodega(http.HandlerFunc(planHandler.GetPlan))).ServeHTTP(w, r)
+		} else if r.Method == http.MethodPost {
+			auth.RequireRole("admin", "bodega")(membresiaService.RequireMiembro(membresia.Mediante(membresia.DelCuerpo("idEvaluacion"), bodegaDeEvaluacion))(http.HandlerFunc(planHandler.GenerarPlan))).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Método no permitido", http.StatusMethodNotAllowed)
+		}
+	})))
+	mux.Handle("/api/planes/items/", auth.AuthMiddleware(auth.RequireRole("admin", "bodega")(membresiaService.RequireMiembro(membresia.Mediante(membresia.DeRuta("/api/planes/items/"), bodegaDeItemPlan))(http.HandlerFunc(planHandler.RouteItem)))))
+
+	// Rutas públicas (sin sesión)
+	mux.HandleFunc("/api/public/bodegas", directorioHandler.ListBodegas)
+	mux.HandleFunc("/api/geo/", geoHandler.Route)
+
+	// Rutas de Comparativa entre bodegas
+	mux.Handle("/api/comparativa", auth.AuthMiddleware(miembroDeBodega(auditorDeBodega(http.HandlerFunc(comparativaHandler.GetComparativa)))))
+
+	// Rutas de Certificados (la verificación es pública; la emisión manual, solo para admin)
+	mux.Handle("/api/certificados", auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			membresiaService.RequireMiembro(membresia.Mediante(membresia.DeConsulta("idEvaluacion"), bodegaDeEvaluacion))(
+				evaluacionHandler.RequireAuditorAsignado(membresia.DeConsulta("idEvaluacion"))(http.HandlerFunc(certificadoHandler.GetCertificado)),
+			).ServeHTTP(w, r)
+		} else if r.Method == http.MethodPost {
+			auth.RequireRole("admin")(http.HandlerFunc(certificadoHandler.EmitirCertificado)).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Método no permitido", http.StatusMethodNotAllowed)
+		}
+	})))
+	mux.HandleFunc("/api/certificados/", certificadoHandler.Route)
+
 	// Rutas de Autenticación con JWT y Cookies
 	mux.HandleFunc("/api/auth/register", usuarioHandler.Register)
 	mux.HandleFunc("/api/auth/login", usuarioHandler.Login)
@@ -194,19 +457,105 @@ func main() {
 	fmt.Println("   GET    /health                      - Estado del servidor")
 	fmt.Println()
 	fmt.Println("   AUTENTICACIÓN (JWT + Cookies):")
-	fmt.Println("   POST   /api/auth/register           - Registrar nuevo usuario")
+	fmt.Println("   POST   /api/auth/register           - Registrar nuevo usuario (rol bodega)")
 	fmt.Println("   POST   /api/auth/login              - Iniciar sesión")
 	fmt.Println("   POST   /api/auth/logout             - Cerrar sesión")
 	fmt.Println("   POST   /api/request-password-reset  - Solicitar recuperación de contraseña")
 	fmt.Println("   POST   /api/reset-password          - Restablecer contraseña")
 	fmt.Println()
-	fmt.Println("   BODEGAS:")
-	fmt.Println("   GET    /api/bodegas                 - Listar bodegas")
+	fmt.Println("   BODEGAS (requieren sesión; el rol bodega solo accede a las bodegas de las que es miembro):")
+	fmt.Println("   GET    /api/bodegas                 - Buscar bodegas, paginado por cursor")
+	fmt.Println("          (?q=&provincia=&idSegmento=&nivel=&desde=&hasta=&orden=nombre|razon_social|created_at|-...&cursor=&limite=)")
 	fmt.Println("   GET    /api/bodegas/{id}            - Obtener bodega por ID")
-	fmt.Println("   POST   /api/bodegas                 - Crear bodega")
+	fmt.Println("   PATCH  /api/bodegas/{id}            - Editar contacto, razón social (propietario), nombre de fantasía, ubicación y actividades")
+	fmt.Println("   PUT    /api/bodegas/{id}/directorio - Aparecer o no en el directorio público (propietario, admin)")
+	fmt.Println("   POST   /api/bodegas                 - Crear bodega (CUIT válido y único; quien la crea queda como propietario)")
+	fmt.Println()
+	fmt.Println("   MIEMBROS DE BODEGAS (requieren sesión):")
+	fmt.Println("   GET    /api/bodegas/{id}/miembros   - Listar miembros de la bodega")
+	fmt.Println("   PUT    /api/bodegas/{id}/miembros/{idUsuario} - Cambiar el rol de un miembro (propietario)")
+	fmt.Println("   DELETE /api/bodegas/{id}/miembros/{idUsuario} - Quitar un miembro (propietario, o uno mismo)")
+	fmt.Println("   GET    /api/bodegas/{id}/invitaciones - Invitaciones pendientes (propietario)")
+	fmt.Println("   POST   /api/bodegas/{id}/invitaciones - Invitar por email con un rol (propietario)")
+	fmt.Println("   DELETE /api/bodegas/{id}/invitaciones/{id} - Revocar invitación (propietario)")
+	fmt.Println("   POST   /api/invitaciones/aceptar    - Aceptar una invitación con su token")
+	fmt.Println("   GET    /api/membresias              - Bodegas del usuario y su rol en cada una")
+	fmt.Println()
+	fmt.Println("   EVALUACIONES (requieren sesión):")
+	fmt.Println("   GET    /api/evaluaciones            - Listar evaluaciones (?idBodega=)")
+	fmt.Println("   POST   /api/evaluaciones            - Iniciar evaluación (bodega o admin)")
+	fmt.Println("   GET    /api/evaluaciones/{id}       - Obtener evaluación por ID")
+	fmt.Println("   GET    /api/evaluaciones/{id}/indicadores - Indicadores aplicables al segmento")
+	fmt.Println("   GET    /api/evaluaciones/{id}/respuestas - Listar respuestas")
+	fmt.Println("   PUT    /api/evaluaciones/{id}/respuestas/{idIndicador} - Guardar respuesta (If-Match: versión, comentario opcional)")
+	fmt.Println("   POST   /api/evaluaciones/{id}/finalizar - Enviar a revisión y calcular puntaje (bodega, admin)")
+	fmt.Println("   POST   /api/evaluaciones/{id}/reabrir - Reabrir una evaluación rechazada (bodega, admin)")
+	fmt.Println("   POST   /api/evaluaciones/{id}/renovar - Renovar una evaluación aprobada o vencida (bodega, admin)")
+	fmt.Println("   GET    /api/evaluaciones/{id}/transiciones - Historial de cambios de estado")
+	fmt.Println("   GET    /api/evaluaciones/{id}/historial - Historial de cambios de las respuestas (?idIndicador=)")
+	fmt.Println("   GET    /api/evaluaciones/{id}/resultado - Puntaje por capítulo (gráfico de radar)")
+	fmt.Println("   GET    /api/evaluaciones/{id}/reporte.pdf - Informe PDF de la evaluación")
+	fmt.Println("   GET    /api/evaluaciones/{id}/comparar/{idOtra} - Comparar con otra evaluación de la bodega")
+	fmt.Println("   GET    /api/niveles-sostenibilidad  - Listar niveles de sostenibilidad (?idSegmento=)")
+	fmt.Println()
+	fmt.Println("   REVISIÓN POR AUDITORES:")
+	fmt.Println("   POST   /api/evaluaciones/{id}/auditor - Asignar auditor a una evaluación enviada (admin)")
+	fmt.Println("   GET    /api/auditor/evaluaciones    - Evaluaciones asignadas al auditor (?estado=)")
+	fmt.Println("   PUT    /api/evaluaciones/{id}/revision/{idIndicador} - Aceptar o ajustar una respuesta (auditor)")
+	fmt.Println("   POST   /api/evaluaciones/{id}/revision - Aprobar o rechazar la evaluación (auditor)")
+	fmt.Println()
+	fmt.Println("   PLAN DE MEJORA (requiere sesión):")
+	fmt.Println("   GET    /api/planes                  - Plan de mejora de una bodega (?idBodega=)")
+	fmt.Println("   POST   /api/planes                  - Generar plan a partir de una evaluación finalizada")
+	fmt.Println("   PUT    /api/planes/items/{id}       - Editar acción, responsable, fecha límite, prioridad o estado")
+	fmt.Println("   DELETE /api/planes/items/{id}       - Quitar ítem del plan")
+	fmt.Println()
+	fmt.Println("   DIRECTORIO PÚBLICO:")
+	fmt.Println("   GET    /api/public/bodegas          - Bodegas con evaluación aprobada vigente (?provincia=&nivel=&pagina=&limite=)")
+	fmt.Println()
+	fmt.Println("   CATÁLOGO GEOGRÁFICO (público):")
+	fmt.Println("   GET    /api/geo/provincias          - Provincias argentinas")
+	fmt.Println("   GET    /api/geo/departamentos       - Departamentos de una provincia (?provincia=)")
+	fmt.Println("   GET    /api/geo/distritos           - Distritos de un departamento (?provincia=&departamento=)")
+	fmt.Println()
+	fmt.Println("   COMPARATIVA (requiere sesión):")
+	fmt.Println("   GET    /api/comparativa             - Estadísticas anónimas de la provincia y segmento (?idBodega=&idSegmento=)")
+	fmt.Println()
+	fmt.Println("   CERTIFICADOS:")
+	fmt.Println("   GET    /api/certificados            - Certificado de una evaluación (?idEvaluacion=, requiere sesión)")
+	fmt.Println("   POST   /api/certificados            - Emitir certificado de una evaluación aprobada (admin)")
+	fmt.Println("   GET    /api/certificados/{codigo}/verificar - Verificar autenticidad y vigencia (público)")
+	fmt.Println("   GET    /api/certificados/clave-publica - Clave pública Ed25519 para verificar firmas (público)")
+	fmt.Println()
+	fmt.Println("   EVIDENCIAS (bodega, auditor y admin):")
+	fmt.Println("   GET    /api/evidencias              - Listar evidencias (?idEvaluacion=&idIndicador=)")
+	fmt.Println("   POST   /api/evidencias              - Subir evidencia (multipart: idEvaluacion, idIndicador, archivo)")
+	fmt.Println("   GET    /api/evidencias/{id}         - Descargar evidencia")
+	fmt.Println("   DELETE /api/evidencias/{id}         - Eliminar evidencia")
+	fmt.Println()
+	fmt.Println("   CATÁLOGO DE INDICADORES:")
+	fmt.Println("   GET    /api/indicadores             - Catálogo publicado (capítulos, indicadores y niveles)")
+	fmt.Println("   GET    /api/catalogo/versiones      - Listar versiones del catálogo (requiere sesión)")
+	fmt.Println("   POST   /api/catalogo/versiones      - Crear versión en borrador (admin)")
+	fmt.Println("   GET    /api/catalogo/versiones/{id} - Capítulos, indicadores y niveles de una versión")
+	fmt.Println("   POST   /api/catalogo/versiones/{id}/publicar - Publicar versión (admin)")
+	fmt.Println("   POST   /api/catalogo/versiones/{id}/retirar  - Retirar versión (admin)")
+	fmt.Println("   PUT    /api/catalogo/versiones/{id}/vigencia - Meses de validez de las evaluaciones (admin)")
+	fmt.Println()
+	fmt.Println("   ADMINISTRACIÓN (admin):")
+	fmt.Println("   POST   /api/admin/registro-inv      - Importar el registro de establecimientos del INV (CSV) y verificar las bodegas")
+	fmt.Println("   GET    /api/admin/registro-inv/verificacion - Verificar los números de INV de las bodegas contra el registro")
+	fmt.Println("   POST   /api/admin/registro-inv/verificacion - Verificar las bodegas contra un archivo del registro sin importarlo")
+	fmt.Println("   GET    /api/admin/exportaciones/evaluaciones - Exportar evaluaciones en CSV o XLSX")
+	fmt.Println("          (?formato=csv|xlsx&modo=evaluacion|respuesta&desde=&hasta=&provincia=&departamento=&idSegmento=&estado=)")
+	fmt.Println()
+	fmt.Println("   SEGMENTOS:")
+	fmt.Println("   GET    /api/segmentos               - Listar segmentos")
+	fmt.Println("   GET    /api/segmentos/{id}          - Obtener segmento por ID")
+	fmt.Println("   GET    /api/segmentos/{id}/indicadores - Indicadores que aplican al segmento")
 	fmt.Println()
 	fmt.Println("   USUARIOS (LEGACY):")
-	fmt.Println("   POST   /api/usuarios                - Crear usuario (usar /api/auth/register)")
+	fmt.Println("   POST   /api/usuarios                - Crear usuario con cualquier rol (admin)")
 	fmt.Println("   POST   /api/usuarios/verificar      - Verificar credenciales (usar /api/auth/login)")
 	fmt.Println("   GET    /api/usuarios                - Listar usuarios")
 	fmt.Println("   GET    /api/usuarios/{id}           - Obtener usuario por ID")
@@ -220,7 +569,8 @@ func homeHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	fmt.Fprintf(w, `{"message":"API COVIAR - Clean Architecture","version":"2.0.0","endpoints":{
 		"usuarios":"/api/usuarios",
-		"bodegas":"/api/bodegas"
+		"bodegas":"/api/bodegas",
+		"evaluaciones":"/api/evaluaciones"
 	}}`)
 }
 
@@ -0,0 +1,159 @@
+// RUTA: coviar-backend/cmd/catalog/main.go
+// Importa el catálogo de capítulos, indicadores y niveles desde un archivo JSON o YAML
+// con la misma estructura que coviar-frontend/lib/assessment/assessment-data.ts.
+//
+// Uso:
+//
+//	go run ./cmd/catalog -archivo scripts/catalogo.json -version 3            # vista previa
+//	go run ./cmd/catalog -archivo scripts/catalogo.json -version 3 -aplicar   # importar
+//	go run ./cmd/catalog -archivo scripts/catalogo.json -nueva "Guía 2026" -aplicar
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/config"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/indicador"
+	"github.com/carli/coviar-backend/internal/platform/database"
+	"github.com/carli/coviar-backend/internal/segmento"
+	"gopkg.in/yaml.v3"
+)
+
+// archivoCatalogo refleja la estructura de assessmentData del frontend
+type archivoCatalogo struct {
+	Chapters []struct {
+		Number     int    `json:"number" yaml:"number"`
+		Name       string `json:"name" yaml:"name"`
+		Indicators []struct {
+			Number      string  `json:"number" yaml:"number"`
+			Name        string  `json:"name" yaml:"name"`
+			Description *string `json:"description" yaml:"description"`
+			Levels      []struct {
+				Level       int    `json:"level" yaml:"level"`
+				Description string `json:"description" yaml:"description"`
+			} `json:"levels" yaml:"levels"`
+		} `json:"indicators" yaml:"indicators"`
+	} `json:"chapters" yaml:"chapters"`
+}
+
+func main() {
+	archivo := flag.String("archivo", "", "ruta del archivo .json, .yaml o .yml a importar")
+	idVersion := flag.Int("version", 0, "ID de la versión en borrador a actualizar")
+	nueva := flag.String("nueva", "", "nombre de una nueva versión en borrador (copia de la publicada)")
+	aplicar := flag.Bool("aplicar", false, "guardar los cambios (sin esta opción solo se muestra la vista previa)")
+	flag.Parse()
+
+	if *archivo == "" || (*idVersion == 0) == (*nueva == "") {
+		fmt.Fprintln(os.Stderr, "uso: catalog -archivo <ruta> (-version <id> | -nueva <nombre>) [-aplicar]")
+		os.Exit(2)
+	}
+
+	catalogo, err := leerArchivo(*archivo)
+	if err != nil {
+		log.Fatalf("❌ Error al leer %s: %v", *archivo, err)
+	}
+
+	cfg := config.Load()
+	db, err := database.Connect(cfg.SupabaseURL, cfg.SupabaseKey)
+	if err != nil {
+		log.Fatal("❌ Error al conectar con Supabase:", err)
+	}
+
+	segmentoService := segmento.NewService(segmento.NewRepository(db))
+	service := indicador.NewService(indicador.NewRepository(db), segmentoService)
+
+	var cambios []indicador.Cambio
+	if *nueva != "" {
+		cambios, err = importarNueva(service, *nueva, catalogo, *aplicar)
+	} else {
+		cambios, err = service.Importar(*idVersion, catalogo, *aplicar)
+	}
+	if err != nil {
+		log.Fatalf("❌ Error al importar: %v", err)
+	}
+
+	imprimirCambios(cambios)
+
+	switch {
+	case len(cambios) == 0:
+		fmt.Println("✅ El catálogo ya está actualizado, no hay cambios")
+	case *aplicar:
+		fmt.Printf("✅ Catálogo importado: %d cambios aplicados\n", len(cambios))
+	default:
+		fmt.Println("ℹ️  Vista previa: use -aplicar para guardar los cambios")
+	}
+}
+
+// importarNueva compara contra la versión publicada y, si se aplica, crea el borrador y lo actualiza
+func importarNueva(service *indicador.Service, nombre string, catalogo *domain.Catalogo, aplicar bool) ([]indicador.Cambio, error) {
+	if !aplicar {
+		publicado, err := service.GetCatalogoVigente()
+		if err != nil && !errors.Is(err, indicador.ErrSinVersionPublicada) {
+			return nil, err
+		}
+		return indicador.Diferencias(publicado, catalogo), nil
+	}
+
+	version, err := service.CrearBorrador(nombre, nil)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("📄 Versión en borrador creada: %d (%s)\n", version.IdVersion, version.Nombre)
+
+	return service.Importar(version.IdVersion, catalogo, true)
+}
+
+func leerArchivo(ruta string) (*domain.Catalogo, error) {
+	contenido, err := os.ReadFile(ruta)
+	if err != nil {
+		return nil, err
+	}
+
+	var archivo archivoCatalogo
+	switch strings.ToLower(filepath.Ext(ruta)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(contenido, &archivo)
+	default:
+		err = json.Unmarshal(contenido, &archivo)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	catalogo := &domain.Catalogo{}
+	for _, chapter := range archivo.Chapters {
+		capitulo := domain.Capitulo{Numero: chapter.Number, Nombre: chapter.Name}
+		for _, indicator := range chapter.Indicators {
+			ind := domain.Indicador{
+				Codigo:      indicator.Number,
+				Nombre:      indicator.Name,
+				Descripcion: indicator.Description,
+			}
+			for _, level := range indicator.Levels {
+				ind.Niveles = append(ind.Niveles, domain.NivelIndicador{Nivel: level.Level, Descripcion: level.Description})
+			}
+			capitulo.Indicadores = append(capitulo.Indicadores, ind)
+		}
+		catalogo.Capitulos = append(catalogo.Capitulos, capitulo)
+	}
+
+	return catalogo, nil
+}
+
+func imprimirCambios(cambios []indicador.Cambio) {
+	resumen := map[string]int{}
+	for _, cambio := range cambios {
+		fmt.Println("  " + cambio.String())
+		resumen[cambio.Tipo]++
+	}
+	fmt.Printf("\nAltas: %d  Modificaciones: %d  Bajas: %d\n",
+		resumen[indicador.CambioAlta], resumen[indicador.CambioModificacion], resumen[indicador.CambioBaja])
+}
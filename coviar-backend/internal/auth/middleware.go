@@ -60,3 +60,34 @@ func OptionalAuthMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// RequireRole verifica que el usuario autenticado tenga alguno de los roles indicados.
+// Debe usarse detrás de AuthMiddleware, que es quien carga las claims en el contexto.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := r.Context().Value("claims").(*Claims)
+			if !ok || claims == nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": "no autorizado - token no encontrado",
+				})
+				return
+			}
+
+			for _, rol := range roles {
+				if claims.Rol == rol {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": "acceso denegado - rol insuficiente",
+			})
+		})
+	}
+}
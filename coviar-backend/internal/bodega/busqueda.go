@@ -0,0 +1,156 @@
+// RUTA: coviar-backend/internal/bodega/busqueda.go
+package bodega
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Límites de la paginación de la búsqueda de bodegas
+const (
+	LimitePorDefecto = 20
+	LimiteMaximo     = 100
+)
+
+// Campos por los que se puede ordenar la búsqueda
+const (
+	OrdenNombre      = "nombre"
+	OrdenRazonSocial = "razon_social"
+	OrdenCreacion    = "created_at"
+)
+
+// ErrCursorInvalido se devuelve cuando el cursor no corresponde a la búsqueda
+var ErrCursorInvalido = errors.New("cursor inválido")
+
+// Filtro limita y ordena la búsqueda de bodegas. Los campos vacíos no filtran.
+type Filtro struct {
+	Texto       string     // parte del nombre, la razón social o el nombre de fantasía
+	Provincia   string     // nombre de la provincia del catálogo geográfico
+	IdSegmento  int        // bodegas con alguna evaluación del segmento
+	Nivel       string     // bodegas con un certificado vigente de ese nivel
+	Desde       *time.Time // fecha de alta desde (inclusive)
+	Hasta       *time.Time // fecha de alta hasta (inclusive)
+	Orden       string     // nombre, razon_social o created_at
+	Descendente bool
+	Cursor      *Cursor // posición donde termina la página anterior
+	Limite      int
+
+	// IdsBodega restringe la búsqueda a esas bodegas (nil no restringe)
+	IdsBodega []int
+}
+
+// Cursor es la última bodega de una página: el valor del campo de orden y su ID, que
+// desempata. Viaja al cliente codificado y opaco.
+type Cursor struct {
+	Orden       string `json:"o"`
+	Descendente bool   `json:"d"`
+	Valor       string `json:"v"`
+	IdBodega    int    `json:"id"`
+}
+
+// PaginaBodegas es una página de la búsqueda de bodegas
+type PaginaBodegas struct {
+	Bodegas         []domain.Bodega `json:"bodegas"`
+	Limite          int             `json:"limite"`
+	Total           int             `json:"total"`
+	HayMas          bool            `json:"hay_mas"`
+	SiguienteCursor *string         `json:"siguiente_cursor"`
+}
+
+// Buscar devuelve una página de bodegas filtradas y ordenadas en la base. Si idUsuario
+// es mayor a 0, solo se buscan las bodegas de las que el usuario es miembro.
+func (s *Service) Buscar(filtro Filtro, idUsuario int) (*PaginaBodegas, error) {
+	if filtro.Limite < 1 {
+		filtro.Limite = LimitePorDefecto
+	}
+	if filtro.Limite > LimiteMaximo {
+		filtro.Limite = LimiteMaximo
+	}
+	if filtro.Orden == "" {
+		filtro.Orden = OrdenNombre
+	}
+	if filtro.Cursor != nil && (filtro.Cursor.Orden != filtro.Orden || filtro.Cursor.Descendente != filtro.Descendente) {
+		return nil, ErrCursorInvalido
+	}
+
+	pagina := &PaginaBodegas{Bodegas: []domain.Bodega{}, Limite: filtro.Limite}
+
+	if idUsuario > 0 {
+		membresias, err := s.miembros.GetMembresias(idUsuario)
+		if err != nil {
+			return nil, err
+		}
+		if len(membresias) == 0 {
+			return pagina, nil
+		}
+		filtro.IdsBodega = make([]int, len(membresias))
+		for i, miembro := range membresias {
+			filtro.IdsBodega[i] = miembro.IdBodega
+		}
+	}
+
+	bodegas, total, err := s.repo.Buscar(filtro)
+	if err != nil {
+		return nil, err
+	}
+	pagina.Total = total
+
+	// El repositorio trae una bodega de más para saber si hay otra página
+	if len(bodegas) > filtro.Limite {
+		bodegas = bodegas[:filtro.Limite]
+		pagina.HayMas = true
+
+		ultima := bodegas[len(bodegas)-1]
+		cursor := CodificarCursor(Cursor{
+			Orden:       filtro.Orden,
+			Descendente: filtro.Descendente,
+			Valor:       valorDeOrden(ultima, filtro.Orden),
+			IdBodega:    ultima.IdBodega,
+		})
+		pagina.SiguienteCursor = &cursor
+	}
+	pagina.Bodegas = bodegas
+
+	return pagina, nil
+}
+
+// CodificarCursor convierte el cursor en el texto opaco que recibe el cliente
+func CodificarCursor(cursor Cursor) string {
+	datos, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(datos)
+}
+
+// DecodificarCursor interpreta el cursor recibido del cliente
+func DecodificarCursor(texto string) (*Cursor, error) {
+	datos, err := base64.RawURLEncoding.DecodeString(texto)
+	if err != nil {
+		return nil, ErrCursorInvalido
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(datos, &cursor); err != nil || cursor.IdBodega <= 0 {
+		return nil, ErrCursorInvalido
+	}
+
+	return &cursor, nil
+}
+
+// OrdenValido indica si se puede ordenar la búsqueda por el campo
+func OrdenValido(orden string) bool {
+	return orden == OrdenNombre || orden == OrdenRazonSocial || orden == OrdenCreacion
+}
+
+func valorDeOrden(bodega domain.Bodega, orden string) string {
+	switch orden {
+	case OrdenRazonSocial:
+		return bodega.RazonSocial
+	case OrdenCreacion:
+		return valorOVacio(bodega.CreatedAt)
+	default:
+		return bodega.Nombre
+	}
+}
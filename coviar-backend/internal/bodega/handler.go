@@ -4,11 +4,16 @@ package bodega
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/carli/coviar-backend/internal/auth"
 	"github.com/carli/coviar-backend/internal/domain"
 )
 
@@ -22,7 +27,9 @@ func NewHandler(service *Service) *Handler {
 	return &Handler{service: service}
 }
 
-// ListBodegas maneja GET /api/bodegas
+// ListBodegas maneja GET /api/bodegas?q=&provincia=&idSegmento=&nivel=&desde=&hasta=&orden=&cursor=&limite=
+// Búsqueda paginada por cursor. orden es nombre, razon_social o created_at, con un guion
+// adelante para ordenar de mayor a menor; las fechas son AAAA-MM-DD.
 func (h *Handler) ListBodegas(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -31,33 +38,119 @@ func (h *Handler) ListBodegas(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bodegas, err := h.service.GetAll()
+	filtro, err := parseFiltro(r.URL.Query())
+	if err != nil {
+		sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Los usuarios con rol bodega solo ven las bodegas de las que son miembros
+	idUsuario := 0
+	if claims, ok := r.Context().Value("claims").(*auth.Claims); ok && claims != nil && claims.Rol == "bodega" {
+		idUsuario = claims.IdUsuario
+	}
+
+	pagina, err := h.service.Buscar(filtro, idUsuario)
 	if err != nil {
 		log.Printf("Error al obtener bodegas: %v", err)
-		sendError(w, "Error al obtener bodegas", http.StatusInternalServerError)
+		if errors.Is(err, ErrCursorInvalido) {
+			sendError(w, "Cursor inválido", http.StatusBadRequest)
+		} else {
+			sendError(w, "Error al obtener bodegas", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	sendSuccess(w, bodegas)
+	sendSuccess(w, pagina)
 }
 
-// GetBodega maneja GET /api/bodegas/{id}
-func (h *Handler) GetBodega(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+// parseFiltro arma el filtro de la búsqueda a partir de los parámetros de la URL
+func parseFiltro(query url.Values) (Filtro, error) {
+	filtro := Filtro{
+		Texto:     strings.TrimSpace(query.Get("q")),
+		Provincia: strings.TrimSpace(query.Get("provincia")),
+		Nivel:     strings.TrimSpace(query.Get("nivel")),
+		Orden:     OrdenNombre,
+	}
 
-	if r.Method != http.MethodGet {
-		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
-		return
+	if param := query.Get("idSegmento"); param != "" {
+		id, err := strconv.Atoi(param)
+		if err != nil || id <= 0 {
+			return filtro, fmt.Errorf("ID de segmento inválido")
+		}
+		filtro.IdSegmento = id
 	}
 
-	// Extraer ID de la URL
-	path := strings.TrimPrefix(r.URL.Path, "/api/bodegas/")
-	id, err := strconv.Atoi(path)
+	for _, campo := range []struct {
+		nombre  string
+		destino **time.Time
+	}{{"desde", &filtro.Desde}, {"hasta", &filtro.Hasta}} {
+		valor := query.Get(campo.nombre)
+		if valor == "" {
+			continue
+		}
+		fecha, err := time.Parse("2006-01-02", valor)
+		if err != nil {
+			return filtro, fmt.Errorf("fecha %s inválida: use AAAA-MM-DD", campo.nombre)
+		}
+		*campo.destino = &fecha
+	}
+
+	if orden := query.Get("orden"); orden != "" {
+		filtro.Descendente = strings.HasPrefix(orden, "-")
+		filtro.Orden = strings.TrimPrefix(orden, "-")
+		if !OrdenValido(filtro.Orden) {
+			return filtro, fmt.Errorf("orden inválido: debe ser nombre, razon_social o created_at")
+		}
+	}
+
+	if param := query.Get("cursor"); param != "" {
+		cursor, err := DecodificarCursor(param)
+		if err != nil {
+			return filtro, err
+		}
+		filtro.Cursor = cursor
+	}
+
+	if param := query.Get("limite"); param != "" {
+		limite, err := strconv.Atoi(param)
+		if err != nil || limite < 1 {
+			return filtro, fmt.Errorf("límite inválido")
+		}
+		filtro.Limite = limite
+	}
+
+	return filtro, nil
+}
+
+// Route despacha las rutas que cuelgan de /api/bodegas/{id}
+func (h *Handler) Route(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extraer ID y acción de la URL
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/bodegas/"), "/")
+	parts := strings.Split(path, "/")
+
+	id, err := strconv.Atoi(parts[0])
 	if err != nil {
 		sendError(w, "ID inválido", http.StatusBadRequest)
 		return
 	}
 
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.GetBodega(w, r, id)
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		h.UpdateBodega(w, r, id)
+	case len(parts) == 2 && parts[1] == "directorio" && r.Method == http.MethodPut:
+		h.UpdateDirectorio(w, r, id)
+	default:
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+// GetBodega maneja GET /api/bodegas/{id}
+func (h *Handler) GetBodega(w http.ResponseWriter, r *http.Request, id int) {
 	bodega, err := h.service.GetByID(id)
 	if err != nil {
 		log.Printf("Error al obtener bodega: %v", err)
@@ -72,6 +165,78 @@ func (h *Handler) GetBodega(w http.ResponseWriter, r *http.Request) {
 	sendSuccess(w, bodega)
 }
 
+// UpdateBodega maneja PATCH /api/bodegas/{id} - Edición parcial del perfil de la bodega
+// (datos de contacto, razón social, nombre de fantasía, ubicación y actividades)
+func (h *Handler) UpdateBodega(w http.ResponseWriter, r *http.Request, id int) {
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	if !ok || claims == nil {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	var cuerpo map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&cuerpo); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+	for campo := range cuerpo {
+		if !CampoEditable(campo) {
+			sendError(w, fmt.Sprintf("%s: %s", ErrCampoNoEditable, campo), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	var cambios CambiosBodega
+	datos, _ := json.Marshal(cuerpo)
+	if err := json.Unmarshal(datos, &cambios); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	bodega, err := h.service.Actualizar(id, cambios, claims)
+	if err != nil {
+		log.Printf("Error al actualizar bodega: %v", err)
+		switch {
+		case errors.Is(err, ErrBodegaNoEncontrada):
+			sendError(w, "Bodega no encontrada", http.StatusNotFound)
+		case errors.Is(err, ErrCampoReservado):
+			sendError(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, ErrSinCambios), errors.Is(err, ErrDatosBodegaInvalidos):
+			sendError(w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			sendError(w, "Error al actualizar bodega", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccess(w, bodega)
+}
+
+// UpdateDirectorio maneja PUT /api/bodegas/{id}/directorio - La bodega elige si aparece
+// en el directorio público de bodegas certificadas
+func (h *Handler) UpdateDirectorio(w http.ResponseWriter, r *http.Request, id int) {
+	var body struct {
+		Excluir *bool `json:"excluir_directorio"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Excluir == nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	bodega, err := h.service.SetExcluirDirectorio(id, *body.Excluir)
+	if err != nil {
+		log.Printf("Error al actualizar directorio de la bodega: %v", err)
+		if errors.Is(err, ErrBodegaNoEncontrada) {
+			sendError(w, "Bodega no encontrada", http.StatusNotFound)
+		} else {
+			sendError(w, "Error al actualizar bodega", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendSuccess(w, bodega)
+}
+
 // CreateBodega maneja POST /api/bodegas - Guardar los datos de la bodega
 func (h *Handler) CreateBodega(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -81,15 +246,44 @@ func (h *Handler) CreateBodega(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var bodega domain.Bodega
-	if err := json.NewDecoder(r.Body).Decode(&bodega); err != nil {
+	// El CUIT se acepta como número o como texto con el formato XX-XXXXXXXX-X
+	var cuerpo struct {
+		domain.Bodega
+		Cuit json.RawMessage `json:"cuit"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&cuerpo); err != nil {
 		sendError(w, "Datos inválidos", http.StatusBadRequest)
 		return
 	}
 
-	if err := h.service.Create(&bodega); err != nil {
+	bodega := cuerpo.Bodega
+	var texto string
+	if err := json.Unmarshal(cuerpo.Cuit, &texto); err != nil {
+		texto = string(cuerpo.Cuit)
+	}
+	cuit, ok := domain.ParseCuit(texto)
+	if !ok {
+		sendError(w, ErrCuitInvalido.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	bodega.Cuit = cuit
+
+	// Quien la registra con rol bodega queda como propietario
+	idPropietario := 0
+	if claims, ok := r.Context().Value("claims").(*auth.Claims); ok && claims != nil && claims.Rol == "bodega" {
+		idPropietario = claims.IdUsuario
+	}
+
+	if err := h.service.Create(&bodega, idPropietario); err != nil {
 		log.Printf("Error al crear bodega: %v", err)
-		sendError(w, err.Error(), http.StatusBadRequest)
+		switch {
+		case errors.Is(err, ErrCuitDuplicado):
+			sendError(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, ErrCuitInvalido), errors.Is(err, ErrInvInvalido), errors.Is(err, ErrInvInconsistente):
+			sendError(w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			sendError(w, err.Error(), http.StatusBadRequest)
+		}
 		return
 	}
 
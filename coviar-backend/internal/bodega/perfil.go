@@ -0,0 +1,277 @@
+// RUTA: coviar-backend/internal/bodega/perfil.go
+package bodega
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/carli/coviar-backend/internal/auth"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/geo"
+	"github.com/carli/coviar-backend/internal/membresia"
+)
+
+// Errores de la edición del perfil de la bodega
+var (
+	ErrSinCambios           = errors.New("no se indicó ningún campo para modificar")
+	ErrCampoNoEditable      = errors.New("el campo no se puede modificar")
+	ErrCampoReservado       = errors.New("solo el propietario de la bodega puede modificar el campo")
+	ErrDatosBodegaInvalidos = errors.New("datos de la bodega inválidos")
+)
+
+// Límites de los campos del perfil
+const (
+	largoMaximoTexto       = 200
+	largoMaximoActividad   = 100
+	cantidadMaxActividades = 20
+)
+
+// camposEditables son los campos que se pueden enviar en la edición parcial. El CUIT, el
+// número de INV y el nombre identifican a la bodega y no se cambian desde el perfil.
+var camposEditables = map[string]bool{
+	"contacto_email":    true,
+	"contacto_telefono": true,
+	"razon_social":      true,
+	"nombre_fantasia":   true,
+	"ubicacion":         true,
+	"provincia":         true,
+	"departamento":      true,
+	"distrito":          true,
+	"actividades":       true,
+}
+
+// CambiosBodega son los campos editables del perfil de la bodega. Los campos ausentes no
+// se modifican; en los opcionales, un texto vacío borra el valor.
+type CambiosBodega struct {
+	ContactoEmail    *string   `json:"contacto_email"`
+	ContactoTelefono *string   `json:"contacto_telefono"`
+	RazonSocial      *string   `json:"razon_social"`
+	NombreFantasia   *string   `json:"nombre_fantasia"`
+	Ubicacion        *string   `json:"ubicacion"`
+	Provincia        *string   `json:"provincia"`
+	Departamento     *string   `json:"departamento"`
+	Distrito         *string   `json:"distrito"`
+	Actividades      *[]string `json:"actividades"`
+}
+
+// CampoEditable indica si el campo se puede enviar en la edición parcial del perfil
+func CampoEditable(campo string) bool {
+	return camposEditables[campo]
+}
+
+// Actualizar aplica una edición parcial al perfil de la bodega. Los editores cambian los
+// datos de contacto, la ubicación y las actividades; la razón social, que es un dato
+// legal, solo la cambian el propietario o un administrador.
+func (s *Service) Actualizar(id int, cambios CambiosBodega, claims *auth.Claims) (*domain.Bodega, error) {
+	actual, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if cambios.RazonSocial != nil {
+		if err := s.miembros.Verificar(claims, id, domain.RolBodegaPropietario); err != nil {
+			if errors.Is(err, membresia.ErrSinAcceso) {
+				return nil, fmt.Errorf("%w: razon_social", ErrCampoReservado)
+			}
+			return nil, err
+		}
+	}
+
+	campos, err := validarCambios(cambios)
+	if err != nil {
+		return nil, err
+	}
+	if cambios.Provincia != nil || cambios.Departamento != nil || cambios.Distrito != nil {
+		ubicacion, err := s.ubicacionActualizada(actual, cambios)
+		if err != nil {
+			return nil, err
+		}
+		campos["provincia"] = ubicacion.Provincia
+		campos["departamento"] = ubicacion.Departamento
+		campos["distrito"] = ubicacion.Distrito
+	}
+	if len(campos) == 0 {
+		return nil, ErrSinCambios
+	}
+	campos["updated_at"] = time.Now().Format(time.RFC3339)
+
+	return s.repo.Update(id, campos)
+}
+
+// validarCambios normaliza los campos enviados y arma el mapa de columnas a guardar
+func validarCambios(cambios CambiosBodega) (map[string]interface{}, error) {
+	campos := make(map[string]interface{})
+
+	if cambios.ContactoEmail != nil {
+		direccion, err := mail.ParseAddress(strings.TrimSpace(*cambios.ContactoEmail))
+		if err != nil || direccion.Name != "" {
+			return nil, fmt.Errorf("%w: el email de contacto no es válido", ErrDatosBodegaInvalidos)
+		}
+		campos["contacto_email"] = strings.ToLower(direccion.Address)
+	}
+
+	if cambios.ContactoTelefono != nil {
+		telefono := strings.TrimSpace(*cambios.ContactoTelefono)
+		if telefono != "" && !telefonoValido(telefono) {
+			return nil, fmt.Errorf("%w: el teléfono de contacto no es válido", ErrDatosBodegaInvalidos)
+		}
+		campos["contacto_telefono"] = textoOpcional(telefono)
+	}
+
+	obligatorios := []struct {
+		columna string
+		nombre  string
+		valor   *string
+	}{
+		{"razon_social", "la razón social", cambios.RazonSocial},
+		{"nombre_fantasia", "el nombre de fantasía", cambios.NombreFantasia},
+		{"ubicacion", "la ubicación", cambios.Ubicacion},
+	}
+	for _, campo := range obligatorios {
+		if campo.valor == nil {
+			continue
+		}
+		texto, err := textoValido(*campo.valor, campo.nombre)
+		if err != nil {
+			return nil, err
+		}
+		if texto == "" {
+			return nil, fmt.Errorf("%w: falta %s", ErrDatosBodegaInvalidos, campo.nombre)
+		}
+		campos[campo.columna] = texto
+	}
+
+	if cambios.Actividades != nil {
+		actividades, err := normalizarActividades(*cambios.Actividades)
+		if err != nil {
+			return nil, err
+		}
+		campos["actividades"] = actividades
+	}
+
+	return campos, nil
+}
+
+// ubicacionActualizada combina la ubicación actual con los cambios y la valida contra el
+// catálogo geográfico. Si cambia la provincia sin indicar departamento, o el departamento
+// sin indicar distrito, los niveles inferiores se borran porque dejan de corresponder.
+func (s *Service) ubicacionActualizada(actual *domain.Bodega, cambios CambiosBodega) (*geo.Ubicacion, error) {
+	provincia := valorOVacio(actual.Provincia)
+	departamento := valorOVacio(actual.Departamento)
+	distrito := valorOVacio(actual.Distrito)
+
+	if cambios.Provincia != nil {
+		provincia = *cambios.Provincia
+		departamento, distrito = "", ""
+	}
+	if cambios.Departamento != nil {
+		departamento = *cambios.Departamento
+		distrito = ""
+	} else if cambios.Provincia != nil && actual.Departamento != nil {
+		// Se conserva el departamento si la provincia enviada es la misma
+		if anterior, err := s.geo.NormalizarProvincia(valorOVacio(actual.Provincia)); err == nil {
+			if nueva, err := s.geo.NormalizarProvincia(provincia); err == nil && nueva == anterior {
+				departamento = *actual.Departamento
+				distrito = valorOVacio(actual.Distrito)
+			}
+		}
+	}
+	if cambios.Distrito != nil {
+		distrito = *cambios.Distrito
+	}
+
+	if strings.TrimSpace(provincia) == "" {
+		return nil, fmt.Errorf("%w: falta la provincia", ErrDatosBodegaInvalidos)
+	}
+
+	ubicacion, err := s.geo.Validar(provincia, departamento, distrito)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatosBodegaInvalidos, err)
+	}
+	if ubicacion.Distrito != nil {
+		if _, err := textoValido(*ubicacion.Distrito, "el distrito"); err != nil {
+			return nil, err
+		}
+	}
+
+	return ubicacion, nil
+}
+
+func valorOVacio(valor *string) string {
+	if valor == nil {
+		return ""
+	}
+	return *valor
+}
+
+// textoValido recorta el texto y controla su largo y que no tenga caracteres de control
+func textoValido(valor string, nombre string) (string, error) {
+	texto := strings.TrimSpace(valor)
+	if len([]rune(texto)) > largoMaximoTexto {
+		return "", fmt.Errorf("%w: %s supera los %d caracteres", ErrDatosBodegaInvalidos, nombre, largoMaximoTexto)
+	}
+	if strings.IndexFunc(texto, unicode.IsControl) >= 0 {
+		return "", fmt.Errorf("%w: %s tiene caracteres no permitidos", ErrDatosBodegaInvalidos, nombre)
+	}
+	return texto, nil
+}
+
+// textoOpcional guarda NULL en lugar de un texto vacío
+func textoOpcional(texto string) interface{} {
+	if texto == "" {
+		return nil
+	}
+	return texto
+}
+
+// telefonoValido acepta dígitos con los separadores habituales (+54 261 123-4567)
+func telefonoValido(telefono string) bool {
+	digitos := 0
+	for i, r := range telefono {
+		switch {
+		case unicode.IsDigit(r):
+			digitos++
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return false
+		}
+	}
+	return digitos >= 6 && digitos <= 15
+}
+
+// normalizarActividades recorta las actividades y descarta las vacías y las repetidas
+func normalizarActividades(actividades []string) ([]string, error) {
+	resultado := make([]string, 0, len(actividades))
+	vistas := make(map[string]bool)
+
+	for _, actividad := range actividades {
+		actividad = strings.TrimSpace(actividad)
+		if actividad == "" {
+			continue
+		}
+		if len([]rune(actividad)) > largoMaximoActividad {
+			return nil, fmt.Errorf("%w: cada actividad puede tener hasta %d caracteres", ErrDatosBodegaInvalidos, largoMaximoActividad)
+		}
+		if strings.IndexFunc(actividad, unicode.IsControl) >= 0 {
+			return nil, fmt.Errorf("%w: las actividades tienen caracteres no permitidos", ErrDatosBodegaInvalidos)
+		}
+
+		clave := strings.ToLower(actividad)
+		if vistas[clave] {
+			continue
+		}
+		vistas[clave] = true
+		resultado = append(resultado, actividad)
+	}
+
+	if len(resultado) > cantidadMaxActividades {
+		return nil, fmt.Errorf("%w: se admiten hasta %d actividades", ErrDatosBodegaInvalidos, cantidadMaxActividades)
+	}
+
+	return resultado, nil
+}
@@ -4,8 +4,12 @@ package bodega
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/supabase-community/postgrest-go"
 	supa "github.com/supabase-community/supabase-go"
 )
 
@@ -19,11 +23,118 @@ func NewRepository(db *supa.Client) *Repository {
 	return &Repository{db: db}
 }
 
-// FindAll obtiene todas las bodegas
-func (r *Repository) FindAll() ([]domain.Bodega, error) {
+// Buscar obtiene las bodegas que cumplen el filtro, ordenadas y a partir del cursor,
+// junto con el total sin paginar. Trae una bodega más que el límite para saber si hay
+// otra página. Todo el filtrado se hace en la base.
+func (r *Repository) Buscar(filtro Filtro) ([]domain.Bodega, int, error) {
+	columnas := "*"
+	if filtro.IdSegmento > 0 {
+		// !inner descarta las bodegas sin evaluaciones aprobadas del segmento
+		columnas += ",evaluacion!inner(idSegmento,estado)"
+	}
+	if filtro.Nivel != "" {
+		// El certificado solo cuenta si su evaluación sigue aprobada: si no, la
+		// verificación pública lo informa como revocado o vencido
+		columnas += ",certificado!inner(nivel,fecha_vencimiento,evaluacion!inner(estado))"
+	}
+
+	_, total, err := r.filtrar(r.db.From("bodega").Select(columnas, "exact", true), filtro, false).
+		Execute()
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orden := postgrest.OrderOpts{Ascending: !filtro.Descendente}
+	data, _, err := r.filtrar(r.db.From("bodega").Select(columnas, "", false), filtro, true).
+		Order(filtro.Orden, &orden).
+		Order("idBodega", &orden).
+		Limit(filtro.Limite+1, "").
+		Execute()
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var bodegas []domain.Bodega
+	if err := json.Unmarshal(data, &bodegas); err != nil {
+		return nil, 0, err
+	}
+
+	return bodegas, int(total), nil
+}
+
+// filtrar aplica los filtros de la búsqueda. PostgREST admite un solo parámetro por
+// clave, así que las condiciones compuestas se agrupan en un único and().
+func (r *Repository) filtrar(query *postgrest.FilterBuilder, filtro Filtro, conCursor bool) *postgrest.FilterBuilder {
+	if filtro.IdsBodega != nil {
+		ids := make([]string, len(filtro.IdsBodega))
+		for i, id := range filtro.IdsBodega {
+			ids[i] = strconv.Itoa(id)
+		}
+		query = query.In("idBodega", ids)
+	}
+	if filtro.Provincia != "" {
+		query = query.Eq("provincia", filtro.Provincia)
+	}
+	if filtro.IdSegmento > 0 {
+		// Las vencidas también cuentan: la bodega fue clasificada en el segmento aunque su
+		// certificación ya no esté vigente. Los borradores y las rechazadas no.
+		query = query.
+			Eq("evaluacion.idSegmento", strconv.Itoa(filtro.IdSegmento)).
+			In("evaluacion.estado", []string{domain.EstadoAprobada, domain.EstadoVencida})
+	}
+	if filtro.Nivel != "" {
+		query = query.
+			Ilike("certificado.nivel", escaparLike(filtro.Nivel)).
+			Gte("certificado.fecha_vencimiento", time.Now().Format("2006-01-02")).
+			Eq("certificado.evaluacion.estado", domain.EstadoAprobada)
+	}
+
+	var condiciones []string
+	if filtro.Texto != "" {
+		patron := citar("*" + escaparLike(filtro.Texto) + "*")
+		condiciones = append(condiciones, fmt.Sprintf("or(nombre.ilike.%s,razon_social.ilike.%s,nombre_fantasia.ilike.%s)", patron, patron, patron))
+	}
+	if filtro.Desde != nil {
+		condiciones = append(condiciones, "created_at.gte."+citar(filtro.Desde.Format(time.RFC3339)))
+	}
+	if filtro.Hasta != nil {
+		condiciones = append(condiciones, "created_at.lt."+citar(filtro.Hasta.AddDate(0, 0, 1).Format(time.RFC3339)))
+	}
+	if conCursor && filtro.Cursor != nil {
+		// Las bodegas que siguen a la última de la página anterior, desempatando por ID
+		operador := "gt"
+		if filtro.Descendente {
+			operador = "lt"
+		}
+		valor := citar(filtro.Cursor.Valor)
+		condiciones = append(condiciones, fmt.Sprintf("or(%s.%s.%s,and(%s.eq.%s,idBodega.%s.%d))",
+			filtro.Orden, operador, valor, filtro.Orden, valor, operador, filtro.Cursor.IdBodega))
+	}
+	if len(condiciones) > 0 {
+		query = query.And(strings.Join(condiciones, ","), "")
+	}
+
+	return query
+}
+
+// citar encierra un valor entre comillas para usarlo en un filtro lógico de PostgREST,
+// donde las comas, los puntos y los paréntesis tienen significado
+func citar(valor string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(valor) + `"`
+}
+
+// escaparLike evita que los comodines del texto buscado se interpreten en ILIKE
+func escaparLike(texto string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(texto)
+}
+
+// FindByID obtiene una bodega por ID
+func (r *Repository) FindByID(id int) (*domain.Bodega, error) {
 	data, _, err := r.db.From("bodega").
 		Select("*", "", false).
-		Order("nombre", nil).
+		Eq("idBodega", fmt.Sprintf("%d", id)).
 		Execute()
 
 	if err != nil {
@@ -35,14 +146,18 @@ func (r *Repository) FindAll() ([]domain.Bodega, error) {
 		return nil, err
 	}
 
-	return bodegas, nil
+	if len(bodegas) == 0 {
+		return nil, ErrBodegaNoEncontrada
+	}
+
+	return &bodegas[0], nil
 }
 
-// FindByID obtiene una bodega por ID
-func (r *Repository) FindByID(id int) (*domain.Bodega, error) {
+// FindByCuit obtiene la bodega con el CUIT dado, o nil si no hay ninguna
+func (r *Repository) FindByCuit(cuit int64) (*domain.Bodega, error) {
 	data, _, err := r.db.From("bodega").
 		Select("*", "", false).
-		Eq("idBodega", fmt.Sprintf("%d", id)).
+		Eq("cuit", strconv.FormatInt(cuit, 10)).
 		Execute()
 
 	if err != nil {
@@ -55,7 +170,7 @@ func (r *Repository) FindByID(id int) (*domain.Bodega, error) {
 	}
 
 	if len(bodegas) == 0 {
-		return nil, fmt.Errorf("bodega no encontrada")
+		return nil, nil
 	}
 
 	return &bodegas[0], nil
@@ -63,15 +178,26 @@ func (r *Repository) FindByID(id int) (*domain.Bodega, error) {
 
 // Create crea una nueva bodega
 func (r *Repository) Create(bodega *domain.Bodega) error {
+	actividades := bodega.Actividades
+	if actividades == nil {
+		actividades = []string{}
+	}
+
 	bodegaMap := map[string]interface{}{
-		"cuit":            bodega.Cuit,
-		"inv":             bodega.Inv,
-		"viñedos_inv":     bodega.ViñedosInv,
-		"nombre":          bodega.Nombre,
-		"ubicacion":       bodega.Ubicacion,
-		"contacto_email":  bodega.ContactoEmail,
-		"razon_social":    bodega.RazonSocial,
-		"nombre_fantasia": bodega.NombreFantasia,
+		"cuit":               bodega.Cuit,
+		"inv":                bodega.Inv,
+		"viñedos_inv":        bodega.ViñedosInv,
+		"nombre":             bodega.Nombre,
+		"ubicacion":          bodega.Ubicacion,
+		"provincia":          bodega.Provincia,
+		"departamento":       bodega.Departamento,
+		"distrito":           bodega.Distrito,
+		"contacto_email":     bodega.ContactoEmail,
+		"contacto_telefono":  bodega.ContactoTelefono,
+		"razon_social":       bodega.RazonSocial,
+		"nombre_fantasia":    bodega.NombreFantasia,
+		"excluir_directorio": bodega.ExcluirDirectorio,
+		"actividades":        actividades,
 	}
 
 	data, _, err := r.db.From("bodega").
@@ -79,6 +205,10 @@ func (r *Repository) Create(bodega *domain.Bodega) error {
 		Execute()
 
 	if err != nil {
+		// 23505: violación de la restricción UNIQUE (cuit), por un alta simultánea
+		if strings.Contains(err.Error(), "23505") || strings.Contains(err.Error(), "duplicate key") {
+			return ErrCuitDuplicado
+		}
 		return err
 	}
 
@@ -93,3 +223,46 @@ func (r *Repository) Create(bodega *domain.Bodega) error {
 
 	return nil
 }
+
+// Delete elimina una bodega
+func (r *Repository) Delete(id int) error {
+	_, _, err := r.db.From("bodega").
+		Delete("minimal", "").
+		Eq("idBodega", fmt.Sprintf("%d", id)).
+		Execute()
+
+	return err
+}
+
+// UpdateExcluirDirectorio cambia si la bodega se muestra en el directorio público
+func (r *Repository) UpdateExcluirDirectorio(id int, excluir bool) error {
+	_, _, err := r.db.From("bodega").
+		Update(map[string]interface{}{"excluir_directorio": excluir}, "", "").
+		Eq("idBodega", fmt.Sprintf("%d", id)).
+		Execute()
+
+	return err
+}
+
+// Update guarda los campos indicados de una bodega y devuelve la bodega actualizada
+func (r *Repository) Update(id int, campos map[string]interface{}) (*domain.Bodega, error) {
+	data, _, err := r.db.From("bodega").
+		Update(campos, "", "").
+		Eq("idBodega", fmt.Sprintf("%d", id)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []domain.Bodega
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, ErrBodegaNoEncontrada
+	}
+
+	return &result[0], nil
+}
@@ -2,24 +2,37 @@
 package bodega
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/geo"
+	"github.com/carli/coviar-backend/internal/inv"
+	"github.com/carli/coviar-backend/internal/membresia"
+)
+
+// Errores de negocio que el handler traduce a códigos HTTP
+var (
+	ErrBodegaNoEncontrada = errors.New("bodega no encontrada")
+	ErrCuitInvalido       = errors.New("CUIT inválido: debe tener 11 dígitos, un tipo válido (20, 23, 24, 27, 30, 33 o 34) y el dígito verificador correcto")
+	ErrCuitDuplicado      = errors.New("ya existe una bodega registrada con ese CUIT")
+	ErrInvInvalido        = errors.New("los números de INV no pueden ser negativos")
+	ErrInvInconsistente   = errors.New("el número de INV no corresponde al CUIT de la bodega")
 )
 
 // Service contiene la lógica de negocio de Bodega
 type Service struct {
-	repo *Repository
+	repo     *Repository
+	miembros *membresia.Service
+	geo      *geo.Service
+	inv      *inv.Service
 }
 
 // NewService crea una nueva instancia del servicio
-func NewService(repo *Repository) *Service {
-	return &Service{repo: repo}
-}
-
-// GetAll obtiene todas las bodegas
-func (s *Service) GetAll() ([]domain.Bodega, error) {
-	return s.repo.FindAll()
+func NewService(repo *Repository, membresiaService *membresia.Service, geoService *geo.Service, invService *inv.Service) *Service {
+	return &Service{repo: repo, miembros: membresiaService, geo: geoService, inv: invService}
 }
 
 // GetByID obtiene una bodega por ID
@@ -31,21 +44,87 @@ func (s *Service) GetByID(id int) (*domain.Bodega, error) {
 	return s.repo.FindByID(id)
 }
 
-// Create crea una nueva bodega con validaciones
-func (s *Service) Create(bodega *domain.Bodega) error {
+// Create crea una nueva bodega con validaciones. Si la crea un usuario con rol bodega
+// (idPropietario > 0), queda como su propietario.
+func (s *Service) Create(bodega *domain.Bodega, idPropietario int) error {
 	// Validaciones de negocio
 	if bodega.Nombre == "" {
 		return fmt.Errorf("el nombre es requerido")
 	}
 
-	if bodega.Cuit <= 0 {
-		return fmt.Errorf("CUIT inválido")
+	// El CUIT es la identidad legal de la bodega: se valida y no puede repetirse
+	if !domain.CuitValido(bodega.Cuit) {
+		return ErrCuitInvalido
+	}
+	existente, err := s.repo.FindByCuit(bodega.Cuit)
+	if err != nil {
+		return err
+	}
+	if existente != nil {
+		return ErrCuitDuplicado
 	}
 
-	// Aquí podrías agregar más validaciones:
-	// - Verificar que el CUIT no exista
-	// - Validar formato de email
-	// - etc.
+	// Los números de INV se comparan con el registro importado: si figuran a nombre de
+	// otro CUIT se rechazan; si no figuran solo se advierte, porque el registro puede
+	// estar desactualizado
+	if bodega.Inv < 0 || bodega.ViñedosInv < 0 {
+		return ErrInvInvalido
+	}
+	observaciones, err := s.inv.Verificar(bodega.Cuit, bodega.Inv, bodega.ViñedosInv)
+	if err != nil {
+		return err
+	}
+	for _, observacion := range observaciones {
+		if observacion.Gravedad == domain.ObservacionError {
+			return fmt.Errorf("%w: %s", ErrInvInconsistente, observacion.Motivo)
+		}
+	}
+
+	// La ubicación estructurada se guarda con los nombres del catálogo geográfico
+	if bodega.Provincia != nil && strings.TrimSpace(*bodega.Provincia) != "" {
+		ubicacion, err := s.geo.Validar(*bodega.Provincia, valorOVacio(bodega.Departamento), valorOVacio(bodega.Distrito))
+		if err != nil {
+			return err
+		}
+		bodega.Provincia = &ubicacion.Provincia
+		bodega.Departamento = ubicacion.Departamento
+		bodega.Distrito = ubicacion.Distrito
+	} else if bodega.Departamento != nil || bodega.Distrito != nil {
+		return fmt.Errorf("para indicar el departamento hay que indicar la provincia")
+	} else {
+		bodega.Provincia = nil
+	}
+
+	if err := s.repo.Create(bodega); err != nil {
+		return err
+	}
+	bodega.ObservacionesInv = observaciones
+
+	// Una bodega sin propietario quedaría inaccesible y su CUIT bloquearía reintentar el
+	// alta, así que si no se puede asignar el propietario se elimina
+	if idPropietario > 0 {
+		if _, err := s.miembros.Agregar(idPropietario, bodega.IdBodega, domain.RolBodegaPropietario); err != nil {
+			if errBorrado := s.repo.Delete(bodega.IdBodega); errBorrado != nil {
+				log.Printf("Error al eliminar la bodega %d sin propietario: %v", bodega.IdBodega, errBorrado)
+			}
+			return fmt.Errorf("no se pudo asignar el propietario de la bodega: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SetExcluirDirectorio cambia si la bodega aparece en el directorio público
+func (s *Service) SetExcluirDirectorio(id int, excluir bool) (*domain.Bodega, error) {
+	bodega, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateExcluirDirectorio(id, excluir); err != nil {
+		return nil, err
+	}
 
-	return s.repo.Create(bodega)
+	bodega.ExcluirDirectorio = excluir
+	return bodega, nil
 }
@@ -0,0 +1,52 @@
+// RUTA: coviar-backend/internal/certificado/firma.go
+package certificado
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+)
+
+// Firmante firma y verifica certificados con una clave Ed25519
+type Firmante struct {
+	privada ed25519.PrivateKey
+	publica ed25519.PublicKey
+}
+
+// NewFirmante crea un firmante a partir de la semilla de 32 bytes en base64
+func NewFirmante(semillaBase64 string) (*Firmante, error) {
+	if semillaBase64 == "" {
+		return nil, fmt.Errorf("falta la clave de firma de los certificados")
+	}
+
+	semilla, err := base64.StdEncoding.DecodeString(semillaBase64)
+	if err != nil {
+		return nil, fmt.Errorf("la clave de firma no es base64 válido: %w", err)
+	}
+
+	if len(semilla) != ed25519.SeedSize {
+		return nil, fmt.Errorf("la clave de firma debe tener %d bytes", ed25519.SeedSize)
+	}
+
+	privada := ed25519.NewKeyFromSeed(semilla)
+	return &Firmante{privada: privada, publica: privada.Public().(ed25519.PublicKey)}, nil
+}
+
+// Firmar devuelve la firma del contenido en base64
+func (f *Firmante) Firmar(contenido string) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(f.privada, []byte(contenido)))
+}
+
+// Verificar indica si la firma corresponde al contenido
+func (f *Firmante) Verificar(contenido string, firmaBase64 string) bool {
+	firma, err := base64.StdEncoding.DecodeString(firmaBase64)
+	if err != nil || len(firma) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(f.publica, []byte(contenido), firma)
+}
+
+// ClavePublica devuelve la clave pública en base64, para verificar certificados sin conexión
+func (f *Firmante) ClavePublica() string {
+	return base64.StdEncoding.EncodeToString(f.publica)
+}
@@ -0,0 +1,143 @@
+// RUTA: coviar-backend/internal/certificado/handler.go
+package certificado
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/bodega"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+)
+
+// Handler maneja las peticiones HTTP de los certificados
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetCertificado maneja GET /api/certificados?idEvaluacion={id}
+func (h *Handler) GetCertificado(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	idEvaluacion, err := strconv.Atoi(r.URL.Query().Get("idEvaluacion"))
+	if err != nil {
+		sendError(w, "ID de evaluación inválido", http.StatusBadRequest)
+		return
+	}
+
+	certificado, err := h.service.GetByEvaluacion(idEvaluacion)
+	if err != nil {
+		log.Printf("Error al obtener certificado: %v", err)
+		sendServiceError(w, err, "Error al obtener certificado", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, certificado)
+}
+
+// EmitirCertificado maneja POST /api/certificados - Emite el certificado de una evaluación aprobada
+func (h *Handler) EmitirCertificado(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var body struct {
+		IdEvaluacion int `json:"idEvaluacion"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.IdEvaluacion <= 0 {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	certificado, err := h.service.Emitir(body.IdEvaluacion)
+	if err != nil {
+		log.Printf("Error al emitir certificado: %v", err)
+		sendServiceError(w, err, "Error al emitir certificado", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	sendSuccess(w, certificado)
+}
+
+// Route despacha las rutas públicas de /api/certificados/ (no requieren sesión)
+func (h *Handler) Route(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/certificados/"), "/")
+	partes := strings.Split(path, "/")
+
+	switch {
+	case path == "clave-publica":
+		sendSuccess(w, map[string]string{"algoritmo": "Ed25519", "clave": h.service.ClavePublica()})
+	case len(partes) == 2 && partes[1] == "verificar":
+		h.Verificar(w, r, partes[0])
+	default:
+		sendError(w, "Ruta no encontrada", http.StatusNotFound)
+	}
+}
+
+// Verificar maneja GET /api/certificados/{codigo}/verificar
+func (h *Handler) Verificar(w http.ResponseWriter, r *http.Request, codigo string) {
+	verificacion, err := h.service.Verificar(codigo)
+	if err != nil {
+		if !errors.Is(err, ErrCertificadoNoEncontrado) {
+			log.Printf("Error al verificar certificado: %v", err)
+		}
+		sendServiceError(w, err, "Error al verificar certificado", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, verificacion)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// sendServiceError traduce los errores de negocio del servicio al código HTTP adecuado
+func sendServiceError(w http.ResponseWriter, err error, fallback string, fallbackStatus int) {
+	switch {
+	case errors.Is(err, ErrCertificadoNoEncontrado), errors.Is(err, evaluacion.ErrEvaluacionNoEncontrada),
+		errors.Is(err, bodega.ErrBodegaNoEncontrada):
+		sendError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrEvaluacionNoAprobada), errors.Is(err, ErrEvaluacionSinNivel):
+		sendError(w, err.Error(), http.StatusConflict)
+	default:
+		sendError(w, fallback, fallbackStatus)
+	}
+}
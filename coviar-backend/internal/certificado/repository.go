@@ -0,0 +1,99 @@
+// RUTA: coviar-backend/internal/certificado/repository.go
+package certificado
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// Repository maneja el acceso a datos de Certificado
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// Create guarda un certificado ya firmado
+func (r *Repository) Create(certificado *domain.Certificado) error {
+	certificadoMap := map[string]interface{}{
+		"codigo":            certificado.Codigo,
+		"idEvaluacion":      certificado.IdEvaluacion,
+		"idBodega":          certificado.IdBodega,
+		"nombre_bodega":     certificado.NombreBodega,
+		"nivel":             certificado.Nivel,
+		"fecha_emision":     certificado.FechaEmision,
+		"fecha_vencimiento": certificado.FechaVencimiento,
+		"firma":             certificado.Firma,
+	}
+
+	data, _, err := r.db.From("certificado").
+		Insert(certificadoMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []domain.Certificado
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*certificado = result[0]
+	}
+
+	return nil
+}
+
+// FindByCodigo obtiene un certificado por su código
+func (r *Repository) FindByCodigo(codigo string) (*domain.Certificado, error) {
+	data, _, err := r.db.From("certificado").
+		Select("*", "", false).
+		Eq("codigo", codigo).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var certificados []domain.Certificado
+	if err := json.Unmarshal(data, &certificados); err != nil {
+		return nil, err
+	}
+
+	if len(certificados) == 0 {
+		return nil, ErrCertificadoNoEncontrado
+	}
+
+	return &certificados[0], nil
+}
+
+// FindByEvaluacion obtiene el certificado emitido para una evaluación
+func (r *Repository) FindByEvaluacion(idEvaluacion int) (*domain.Certificado, error) {
+	data, _, err := r.db.From("certificado").
+		Select("*", "", false).
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var certificados []domain.Certificado
+	if err := json.Unmarshal(data, &certificados); err != nil {
+		return nil, err
+	}
+
+	if len(certificados) == 0 {
+		return nil, ErrCertificadoNoEncontrado
+	}
+
+	return &certificados[0], nil
+}
@@ -0,0 +1,243 @@
+// RUTA: coviar-backend/internal/certificado/service.go
+package certificado
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/bodega"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+	"github.com/carli/coviar-backend/internal/puntaje"
+)
+
+// Errores de negocio que el handler traduce a códigos HTTP
+var (
+	ErrCertificadoNoEncontrado = errors.New("certificado no encontrado")
+	ErrEvaluacionNoAprobada    = errors.New("solo se certifican evaluaciones aprobadas")
+	ErrEvaluacionSinNivel      = errors.New("la evaluación no alcanzó ningún nivel de sostenibilidad")
+)
+
+// MesesVigencia es el tiempo durante el cual el certificado es válido desde su emisión
+// cuando la evaluación no tiene fecha de vencimiento propia
+const MesesVigencia = 24
+
+// Estados posibles del resultado de una verificación
+const (
+	EstadoVigente  = "vigente"
+	EstadoVencido  = "vencido"
+	EstadoInvalido = "invalido" // los datos no coinciden con la firma
+	EstadoRevocado = "revocado" // la evaluación certificada ya no está aprobada
+)
+
+// formatoFecha es el formato de las fechas firmadas (columnas DATE en la base)
+const formatoFecha = "2006-01-02"
+
+// codificacionCodigo genera códigos sin relleno con letras mayúsculas y dígitos
+var codificacionCodigo = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Verificacion es la respuesta pública a la consulta de un certificado
+type Verificacion struct {
+	Codigo      string              `json:"codigo"`
+	Autentico   bool                `json:"autentico"`
+	Vigente     bool                `json:"vigente"`
+	Estado      string              `json:"estado"`
+	Certificado *domain.Certificado `json:"certificado"`
+}
+
+// Service contiene la lógica de emisión y verificación de certificados
+type Service struct {
+	repo         *Repository
+	firmante     *Firmante
+	evaluaciones *evaluacion.Service
+	bodegas      *bodega.Service
+	puntaje      *puntaje.Service
+	urlPublica   string
+}
+
+// NewService crea una nueva instancia del servicio. urlPublica es la dirección de la
+// API con la que se arman los enlaces de verificación.
+func NewService(repo *Repository, firmante *Firmante, evaluacionService *evaluacion.Service, bodegaService *bodega.Service, puntajeService *puntaje.Service, urlPublica string) *Service {
+	return &Service{
+		repo:         repo,
+		firmante:     firmante,
+		evaluaciones: evaluacionService,
+		bodegas:      bodegaService,
+		puntaje:      puntajeService,
+		urlPublica:   strings.TrimRight(urlPublica, "/"),
+	}
+}
+
+// Emitir emite y firma el certificado de una evaluación aprobada. Si ya tenía uno
+// se devuelve el existente.
+func (s *Service) Emitir(idEvaluacion int) (*domain.Certificado, error) {
+	existente, err := s.GetByEvaluacion(idEvaluacion)
+	if err == nil {
+		return existente, nil
+	}
+	if !errors.Is(err, ErrCertificadoNoEncontrado) {
+		return nil, err
+	}
+
+	ev, err := s.evaluaciones.GetByID(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	if ev.Estado != domain.EstadoAprobada {
+		return nil, ErrEvaluacionNoAprobada
+	}
+	if ev.IdNvSos == nil {
+		return nil, ErrEvaluacionSinNivel
+	}
+
+	nivel, _, err := s.puntaje.UbicarNivel(ev.IdSegmento, *ev.IdNvSos)
+	if err != nil {
+		return nil, err
+	}
+	if nivel == nil {
+		return nil, ErrEvaluacionSinNivel
+	}
+
+	bod, err := s.bodegas.GetByID(ev.IdBodega)
+	if err != nil {
+		return nil, err
+	}
+
+	codigo, err := generarCodigo()
+	if err != nil {
+		return nil, err
+	}
+
+	// El certificado vence junto con la evaluación aprobada
+	emision := time.Now()
+	vencimiento := emision.AddDate(0, MesesVigencia, 0)
+	if ev.FechaVencimiento != nil {
+		if fecha, err := time.Parse(time.RFC3339, *ev.FechaVencimiento); err == nil {
+			vencimiento = fecha
+		}
+	}
+
+	certificado := &domain.Certificado{
+		Codigo:           codigo,
+		IdEvaluacion:     ev.IdEvaluacion,
+		IdBodega:         bod.IdBodega,
+		NombreBodega:     bod.Nombre,
+		Nivel:            nivel.Nombre,
+		FechaEmision:     emision.Format(formatoFecha),
+		FechaVencimiento: vencimiento.Format(formatoFecha),
+	}
+	certificado.Firma = s.firmante.Firmar(certificado.Contenido())
+
+	if err := s.repo.Create(certificado); err != nil {
+		return nil, err
+	}
+
+	return certificado, nil
+}
+
+// GetByEvaluacion obtiene el certificado emitido para una evaluación
+func (s *Service) GetByEvaluacion(idEvaluacion int) (*domain.Certificado, error) {
+	if idEvaluacion <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+
+	return s.repo.FindByEvaluacion(idEvaluacion)
+}
+
+// Verificar comprueba que los datos guardados de un certificado coincidan con su firma,
+// que la evaluación certificada siga aprobada y que no esté vencido
+func (s *Service) Verificar(codigo string) (*Verificacion, error) {
+	codigo = strings.ToUpper(strings.TrimSpace(codigo))
+	if codigo == "" {
+		return nil, ErrCertificadoNoEncontrado
+	}
+
+	certificado, err := s.repo.FindByCodigo(codigo)
+	if err != nil {
+		return nil, err
+	}
+
+	verificacion := &Verificacion{
+		Codigo:      certificado.Codigo,
+		Autentico:   s.firmante.Verificar(certificado.Contenido(), certificado.Firma),
+		Certificado: certificado,
+	}
+
+	// La firma no alcanza: la evaluación pudo dejar de estar aprobada después de la
+	// emisión (por ejemplo, las que se certificaron como completadas antes de la revisión
+	// por auditores y luego se rechazaron)
+	estadoEvaluacion, err := s.estadoEvaluacion(certificado.IdEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !verificacion.Autentico:
+		verificacion.Estado = EstadoInvalido
+	case estadoEvaluacion == domain.EstadoVencida:
+		verificacion.Estado = EstadoVencido
+	case estadoEvaluacion != domain.EstadoAprobada:
+		verificacion.Estado = EstadoRevocado
+	case vencido(certificado, time.Now()):
+		verificacion.Estado = EstadoVencido
+	default:
+		verificacion.Estado = EstadoVigente
+		verificacion.Vigente = true
+	}
+
+	return verificacion, nil
+}
+
+// estadoEvaluacion devuelve el estado actual de la evaluación certificada, o texto vacío
+// si ya no existe
+func (s *Service) estadoEvaluacion(idEvaluacion int) (string, error) {
+	ev, err := s.evaluaciones.GetByID(idEvaluacion)
+	if errors.Is(err, evaluacion.ErrEvaluacionNoEncontrada) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return ev.Estado, nil
+}
+
+// ClavePublica devuelve la clave con la que se pueden verificar las firmas sin conexión
+func (s *Service) ClavePublica() string {
+	return s.firmante.ClavePublica()
+}
+
+// URLVerificacion devuelve la dirección pública donde se verifica un certificado
+func (s *Service) URLVerificacion(codigo string) string {
+	return s.urlPublica + "/api/certificados/" + codigo + "/verificar"
+}
+
+// vencido indica si el certificado ya no es válido en la fecha dada. El certificado
+// vale hasta el final del día de vencimiento.
+func vencido(certificado *domain.Certificado, ahora time.Time) bool {
+	vencimiento, err := time.ParseInLocation(formatoFecha, certificado.FechaVencimiento, ahora.Location())
+	if err != nil {
+		return true
+	}
+	return !ahora.Before(vencimiento.AddDate(0, 0, 1))
+}
+
+// generarCodigo crea un código aleatorio legible con el formato COV-XXXX-XXXX-XXXX-XXXX
+func generarCodigo() (string, error) {
+	aleatorio := make([]byte, 10)
+	if _, err := rand.Read(aleatorio); err != nil {
+		return "", err
+	}
+
+	texto := codificacionCodigo.EncodeToString(aleatorio)
+	grupos := []string{"COV"}
+	for i := 0; i < len(texto); i += 4 {
+		grupos = append(grupos, texto[i:i+4])
+	}
+
+	return strings.Join(grupos, "-"), nil
+}
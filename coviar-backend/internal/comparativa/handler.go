@@ -0,0 +1,99 @@
+// RUTA: coviar-backend/internal/comparativa/handler.go
+package comparativa
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/carli/coviar-backend/internal/bodega"
+	"github.com/carli/coviar-backend/internal/segmento"
+)
+
+// Handler maneja las peticiones HTTP de la comparativa entre bodegas
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetComparativa maneja GET /api/comparativa?idBodega={id}&idSegmento={id} - Estadísticas
+// anónimas de las bodegas de la misma provincia y segmento. idSegmento es opcional.
+func (h *Handler) GetComparativa(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+
+	idBodega, err := strconv.Atoi(query.Get("idBodega"))
+	if err != nil || idBodega <= 0 {
+		sendError(w, "ID de bodega inválido", http.StatusBadRequest)
+		return
+	}
+
+	idSegmento := 0
+	if param := query.Get("idSegmento"); param != "" {
+		idSegmento, err = strconv.Atoi(param)
+		if err != nil || idSegmento <= 0 {
+			sendError(w, "ID de segmento inválido", http.StatusBadRequest)
+			return
+		}
+	}
+
+	comparativa, err := h.service.GetComparativa(idBodega, idSegmento)
+	if err != nil {
+		log.Printf("Error al obtener comparativa: %v", err)
+		sendServiceError(w, err, "Error al obtener comparativa", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, comparativa)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// sendServiceError traduce los errores de negocio del servicio al código HTTP adecuado
+func sendServiceError(w http.ResponseWriter, err error, fallback string, fallbackStatus int) {
+	switch {
+	case errors.Is(err, bodega.ErrBodegaNoEncontrada), errors.Is(err, segmento.ErrSegmentoNoEncontrado):
+		sendError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrBodegaSinProvincia), errors.Is(err, ErrBodegaSinSegmento):
+		sendError(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		sendError(w, fallback, fallbackStatus)
+	}
+}
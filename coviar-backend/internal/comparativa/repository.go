@@ -0,0 +1,92 @@
+// RUTA: coviar-backend/internal/comparativa/repository.go
+package comparativa
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/platform/database"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// indicadorResumido son los datos de un indicador con los que se agrupan las respuestas
+// de distintas versiones del catálogo
+type indicadorResumido struct {
+	IdIndicador int    `json:"idIndicador"`
+	Codigo      string `json:"codigo"`
+	Nombre      string `json:"nombre"`
+}
+
+// Repository lee los datos con los que se arma la comparativa
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindAprobadas obtiene las evaluaciones aprobadas de un segmento cuyas bodegas son de
+// la provincia dada
+func (r *Repository) FindAprobadas(provincia string, idSegmento int) ([]domain.Evaluacion, error) {
+	// !inner descarta las evaluaciones cuya bodega no es de la provincia
+	return database.LeerPorTandas(func() *postgrest.FilterBuilder {
+		return r.db.From("evaluacion").
+			Select("*,bodega!inner(provincia)", "", false).
+			Eq("bodega.provincia", provincia).
+			Eq("idSegmento", strconv.Itoa(idSegmento)).
+			Eq("estado", domain.EstadoAprobada)
+	}, "idEvaluacion", func(evaluacion *domain.Evaluacion) int { return evaluacion.IdEvaluacion })
+}
+
+// FindRespuestas obtiene todas las respuestas de las evaluaciones dadas
+func (r *Repository) FindRespuestas(idsEvaluacion []int) ([]domain.Respuesta, error) {
+	if len(idsEvaluacion) == 0 {
+		return nil, nil
+	}
+
+	return database.LeerPorTandas(func() *postgrest.FilterBuilder {
+		return r.db.From("respuesta").
+			Select("*", "", false).
+			In("idEvaluacion", database.ToStrings(idsEvaluacion))
+	}, "idRespuesta", func(respuesta *domain.Respuesta) int { return respuesta.IdRespuesta })
+}
+
+// FindPuntajesCapitulo obtiene los puntajes por capítulo de las evaluaciones dadas
+func (r *Repository) FindPuntajesCapitulo(idsEvaluacion []int) ([]domain.PuntajeCapitulo, error) {
+	if len(idsEvaluacion) == 0 {
+		return nil, nil
+	}
+
+	return database.LeerPorTandas(func() *postgrest.FilterBuilder {
+		return r.db.From("puntaje_capitulo").
+			Select("*", "", false).
+			In("idEvaluacion", database.ToStrings(idsEvaluacion))
+	}, "idPuntajeCapitulo", func(puntaje *domain.PuntajeCapitulo) int { return puntaje.IdPuntajeCapitulo })
+}
+
+// FindIndicadores obtiene el código y el nombre de los indicadores dados
+func (r *Repository) FindIndicadores(idsIndicador []int) ([]indicadorResumido, error) {
+	if len(idsIndicador) == 0 {
+		return nil, nil
+	}
+
+	data, _, err := r.db.From("indicador").
+		Select(`"idIndicador",codigo,nombre`, "", false).
+		In("idIndicador", database.ToStrings(idsIndicador)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var indicadores []indicadorResumido
+	if err := json.Unmarshal(data, &indicadores); err != nil {
+		return nil, err
+	}
+
+	return indicadores, nil
+}
@@ -0,0 +1,294 @@
+// RUTA: coviar-backend/internal/comparativa/service.go
+package comparativa
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/carli/coviar-backend/internal/bodega"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+	"github.com/carli/coviar-backend/internal/segmento"
+)
+
+// Errores de negocio que el handler traduce a códigos HTTP
+var (
+	ErrBodegaSinProvincia = errors.New("la bodega no tiene provincia cargada")
+	ErrBodegaSinSegmento  = errors.New("la bodega no tiene evaluaciones de las que tomar el segmento")
+)
+
+// TamanoMinimoGrupo es la cantidad mínima de bodegas (k-anonimato) para mostrar las
+// estadísticas de un grupo. Con menos, el grupo se suprime para que no se pueda
+// identificar a ninguna bodega.
+const TamanoMinimoGrupo = 5
+
+// Estadisticas resume la distribución de un valor en un grupo de bodegas
+type Estadisticas struct {
+	Media   float64 `json:"media"`
+	Mediana float64 `json:"mediana"`
+	Q1      float64 `json:"q1"`
+	Q3      float64 `json:"q3"`
+}
+
+// EstadisticaIndicador resume los niveles finales de un indicador. Las respuestas de
+// distintas versiones del catálogo se agrupan por código.
+type EstadisticaIndicador struct {
+	Codigo       string        `json:"codigo"`
+	Nombre       string        `json:"nombre"`
+	Cantidad     int           `json:"cantidad"` // bodegas que respondieron
+	Suprimido    bool          `json:"suprimido"`
+	Estadisticas *Estadisticas `json:"estadisticas,omitempty"`
+	Distribucion map[int]int   `json:"distribucion,omitempty"` // nivel -> cantidad de bodegas
+}
+
+// EstadisticaCapitulo resume el porcentaje obtenido en un capítulo
+type EstadisticaCapitulo struct {
+	Numero       int           `json:"numero"`
+	Nombre       string        `json:"nombre"`
+	Cantidad     int           `json:"cantidad"`
+	Suprimido    bool          `json:"suprimido"`
+	Estadisticas *Estadisticas `json:"estadisticas,omitempty"`
+}
+
+// Comparativa son las estadísticas anónimas de las bodegas de una provincia y segmento
+type Comparativa struct {
+	Provincia       string                 `json:"provincia"`
+	IdSegmento      int                    `json:"idSegmento"`
+	Segmento        string                 `json:"segmento"`
+	CantidadBodegas int                    `json:"cantidad_bodegas"`
+	TamanoMinimo    int                    `json:"tamano_minimo"`
+	Suprimida       bool                   `json:"suprimida"` // el grupo completo es menor al mínimo
+	Capitulos       []EstadisticaCapitulo  `json:"capitulos"`
+	Indicadores     []EstadisticaIndicador `json:"indicadores"`
+}
+
+// Service calcula la comparativa de una bodega con sus pares
+type Service struct {
+	repo         *Repository
+	bodegas      *bodega.Service
+	evaluaciones *evaluacion.Service
+	segmentos    *segmento.Service
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, bodegaService *bodega.Service, evaluacionService *evaluacion.Service, segmentoService *segmento.Service) *Service {
+	return &Service{
+		repo:         repo,
+		bodegas:      bodegaService,
+		evaluaciones: evaluacionService,
+		segmentos:    segmentoService,
+	}
+}
+
+// GetComparativa calcula las estadísticas de las bodegas de la misma provincia que la
+// dada, en el segmento indicado o, si es 0, en el de su última evaluación. Solo cuenta
+// la última evaluación aprobada de cada bodega.
+func (s *Service) GetComparativa(idBodega int, idSegmento int) (*Comparativa, error) {
+	bod, err := s.bodegas.GetByID(idBodega)
+	if err != nil {
+		return nil, err
+	}
+	if bod.Provincia == nil || *bod.Provincia == "" {
+		return nil, ErrBodegaSinProvincia
+	}
+
+	if idSegmento == 0 {
+		idSegmento, err = s.segmentoActual(idBodega)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seg, err := s.segmentos.GetByID(idSegmento)
+	if err != nil {
+		return nil, err
+	}
+
+	comparativa := &Comparativa{
+		Provincia:    *bod.Provincia,
+		IdSegmento:   seg.IdSegmento,
+		Segmento:     seg.Nombre,
+		TamanoMinimo: TamanoMinimoGrupo,
+		Capitulos:    []EstadisticaCapitulo{},
+		Indicadores:  []EstadisticaIndicador{},
+	}
+
+	aprobadas, err := s.repo.FindAprobadas(*bod.Provincia, idSegmento)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := ultimaPorBodega(aprobadas)
+	comparativa.CantidadBodegas = len(ids)
+	if len(ids) < TamanoMinimoGrupo {
+		comparativa.Suprimida = true
+		return comparativa, nil
+	}
+
+	if comparativa.Capitulos, err = s.estadisticasCapitulos(ids); err != nil {
+		return nil, err
+	}
+	if comparativa.Indicadores, err = s.estadisticasIndicadores(ids); err != nil {
+		return nil, err
+	}
+
+	return comparativa, nil
+}
+
+// segmentoActual devuelve el segmento de la evaluación más reciente de la bodega
+func (s *Service) segmentoActual(idBodega int) (int, error) {
+	evaluaciones, err := s.evaluaciones.GetByBodega(idBodega)
+	if err != nil {
+		return 0, err
+	}
+
+	idSegmento, ultima := 0, 0
+	for _, ev := range evaluaciones {
+		if ev.IdEvaluacion > ultima {
+			idSegmento, ultima = ev.IdSegmento, ev.IdEvaluacion
+		}
+	}
+	if idSegmento == 0 {
+		return 0, ErrBodegaSinSegmento
+	}
+
+	return idSegmento, nil
+}
+
+func (s *Service) estadisticasCapitulos(idsEvaluacion []int) ([]EstadisticaCapitulo, error) {
+	puntajes, err := s.repo.FindPuntajesCapitulo(idsEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	porcentajes := make(map[int][]float64)
+	nombres := make(map[int]string)
+	for _, puntaje := range puntajes {
+		porcentajes[puntaje.NumeroCapitulo] = append(porcentajes[puntaje.NumeroCapitulo], puntaje.Porcentaje)
+		nombres[puntaje.NumeroCapitulo] = puntaje.Nombre
+	}
+
+	capitulos := make([]EstadisticaCapitulo, 0, len(porcentajes))
+	for numero, valores := range porcentajes {
+		capitulo := EstadisticaCapitulo{Numero: numero, Nombre: nombres[numero], Cantidad: len(valores)}
+		if len(valores) < TamanoMinimoGrupo {
+			capitulo.Suprimido = true
+		} else {
+			capitulo.Estadisticas = calcularEstadisticas(valores)
+		}
+		capitulos = append(capitulos, capitulo)
+	}
+	sort.Slice(capitulos, func(a, b int) bool { return capitulos[a].Numero < capitulos[b].Numero })
+
+	return capitulos, nil
+}
+
+func (s *Service) estadisticasIndicadores(idsEvaluacion []int) ([]EstadisticaIndicador, error) {
+	respuestas, err := s.repo.FindRespuestas(idsEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	vistos := make(map[int]bool)
+	var idsIndicador []int
+	for _, respuesta := range respuestas {
+		if !vistos[respuesta.IdIndicador] {
+			vistos[respuesta.IdIndicador] = true
+			idsIndicador = append(idsIndicador, respuesta.IdIndicador)
+		}
+	}
+
+	resumidos, err := s.repo.FindIndicadores(idsIndicador)
+	if err != nil {
+		return nil, err
+	}
+	porId := make(map[int]indicadorResumido, len(resumidos))
+	for _, ind := range resumidos {
+		porId[ind.IdIndicador] = ind
+	}
+
+	niveles := make(map[string][]float64)
+	nombres := make(map[string]string)
+	for _, respuesta := range respuestas {
+		ind, ok := porId[respuesta.IdIndicador]
+		if !ok {
+			continue
+		}
+		niveles[ind.Codigo] = append(niveles[ind.Codigo], float64(respuesta.NivelFinal()))
+		nombres[ind.Codigo] = ind.Nombre
+	}
+
+	indicadores := make([]EstadisticaIndicador, 0, len(niveles))
+	for codigo, valores := range niveles {
+		indicador := EstadisticaIndicador{Codigo: codigo, Nombre: nombres[codigo], Cantidad: len(valores)}
+		if len(valores) < TamanoMinimoGrupo {
+			indicador.Suprimido = true
+		} else {
+			indicador.Estadisticas = calcularEstadisticas(valores)
+			indicador.Distribucion = make(map[int]int)
+			for nivel := domain.NivelMinimo; nivel <= domain.NivelMaximo; nivel++ {
+				indicador.Distribucion[nivel] = 0
+			}
+			for _, valor := range valores {
+				indicador.Distribucion[int(valor)]++
+			}
+		}
+		indicadores = append(indicadores, indicador)
+	}
+	sort.Slice(indicadores, func(a, b int) bool {
+		return domain.CompararCodigos(indicadores[a].Codigo, indicadores[b].Codigo) < 0
+	})
+
+	return indicadores, nil
+}
+
+// ultimaPorBodega devuelve el ID de la última evaluación aprobada de cada bodega, para
+// que ninguna cuente más de una vez
+func ultimaPorBodega(evaluaciones []domain.Evaluacion) []int {
+	ultimas := make(map[int]int)
+	for _, ev := range evaluaciones {
+		if ev.IdEvaluacion > ultimas[ev.IdBodega] {
+			ultimas[ev.IdBodega] = ev.IdEvaluacion
+		}
+	}
+
+	ids := make([]int, 0, len(ultimas))
+	for _, id := range ultimas {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// calcularEstadisticas calcula media, mediana y cuartiles (interpolación lineal entre
+// los valores ordenados), redondeados a dos decimales
+func calcularEstadisticas(valores []float64) *Estadisticas {
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+
+	suma := 0.0
+	for _, valor := range ordenados {
+		suma += valor
+	}
+
+	return &Estadisticas{
+		Media:   redondear(suma / float64(len(ordenados))),
+		Mediana: redondear(cuantil(ordenados, 0.5)),
+		Q1:      redondear(cuantil(ordenados, 0.25)),
+		Q3:      redondear(cuantil(ordenados, 0.75)),
+	}
+}
+
+// cuantil devuelve el cuantil p de valores ya ordenados
+func cuantil(ordenados []float64, p float64) float64 {
+	posicion := p * float64(len(ordenados)-1)
+	inferior := int(math.Floor(posicion))
+	superior := int(math.Ceil(posicion))
+	fraccion := posicion - float64(inferior)
+	return ordenados[inferior] + (ordenados[superior]-ordenados[inferior])*fraccion
+}
+
+func redondear(valor float64) float64 {
+	return math.Round(valor*100) / 100
+}
@@ -13,6 +13,19 @@ type Config struct {
 	SupabaseURL string
 	SupabaseKey string
 	Port        string
+	// Directorio donde se guardan los archivos de evidencia
+	EvidenciasDir string
+	// Semilla Ed25519 en base64 con la que se firman los certificados
+	CertificadoClave string
+	// URL pública de la API, usada en los enlaces de verificación de certificados
+	PublicURL string
+	// Servidor SMTP para los correos de la aplicación (recordatorios de vencimiento)
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+	// URL del frontend, usada en los enlaces de los correos
+	FrontendURL string
 }
 
 // Load carga las variables de entorno desde .env
@@ -23,9 +36,33 @@ func Load() *Config {
 	}
 
 	cfg := &Config{
-		SupabaseURL: os.Getenv("SUPABASE_URL"),
-		SupabaseKey: os.Getenv("SUPABASE_KEY"),
-		Port:        os.Getenv("APP_PORT"),
+		SupabaseURL:      os.Getenv("SUPABASE_URL"),
+		SupabaseKey:      os.Getenv("SUPABASE_KEY"),
+		Port:             os.Getenv("APP_PORT"),
+		EvidenciasDir:    os.Getenv("EVIDENCIAS_DIR"),
+		CertificadoClave: os.Getenv("CERTIFICADO_CLAVE_PRIVADA"),
+		PublicURL:        os.Getenv("API_PUBLIC_URL"),
+		SMTPHost:         os.Getenv("SMTP_HOST"),
+		SMTPPort:         os.Getenv("SMTP_PORT"),
+		SMTPUser:         os.Getenv("SMTP_USER"),
+		SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
+		FrontendURL:      os.Getenv("FRONTEND_URL"),
+	}
+
+	if cfg.EvidenciasDir == "" {
+		cfg.EvidenciasDir = "uploads/evidencias"
+	}
+
+	if cfg.SMTPHost == "" {
+		cfg.SMTPHost = "smtp.gmail.com"
+	}
+
+	if cfg.SMTPPort == "" {
+		cfg.SMTPPort = "587"
+	}
+
+	if cfg.FrontendURL == "" {
+		cfg.FrontendURL = "http://localhost:3000"
 	}
 
 	// Validar variables críticas
@@ -33,5 +70,11 @@ func Load() *Config {
 		log.Fatal("❌ ERROR: SUPABASE_URL y SUPABASE_KEY son requeridas")
 	}
 
+	// Sin la clave los certificados emitidos dejarían de verificarse al reiniciar, y sin la
+	// URL los códigos QR apuntarían a otro servidor
+	if cfg.CertificadoClave == "" || cfg.PublicURL == "" {
+		log.Fatal("❌ ERROR: CERTIFICADO_CLAVE_PRIVADA y API_PUBLIC_URL son requeridas")
+	}
+
 	return cfg
 }
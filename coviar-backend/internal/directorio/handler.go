@@ -0,0 +1,105 @@
+// RUTA: coviar-backend/internal/directorio/handler.go
+package directorio
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// maxAge es el tiempo, en segundos, que navegadores y proxies pueden reutilizar el directorio
+const maxAge = 300
+
+// Handler maneja las peticiones HTTP del directorio público
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListBodegas maneja GET /api/public/bodegas?provincia=&nivel=&pagina=&limite= - Bodegas
+// con una evaluación aprobada vigente. No requiere sesión y se puede cachear: responde
+// con ETag y 304 Not Modified si el contenido no cambió.
+func (h *Handler) ListBodegas(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	filtro := Filtro{
+		Provincia: query.Get("provincia"),
+		Nivel:     query.Get("nivel"),
+	}
+
+	var err error
+	if param := query.Get("pagina"); param != "" {
+		if filtro.Pagina, err = strconv.Atoi(param); err != nil || filtro.Pagina < 1 {
+			sendError(w, "Página inválida", http.StatusBadRequest)
+			return
+		}
+	}
+	if param := query.Get("limite"); param != "" {
+		if filtro.Limite, err = strconv.Atoi(param); err != nil || filtro.Limite < 1 {
+			sendError(w, "Límite inválido", http.StatusBadRequest)
+			return
+		}
+	}
+
+	pagina, err := h.service.Listar(filtro)
+	if err != nil {
+		log.Printf("Error al obtener directorio de bodegas: %v", err)
+		sendError(w, "Error al obtener directorio de bodegas", http.StatusInternalServerError)
+		return
+	}
+
+	var cuerpo bytes.Buffer
+	if err := json.NewEncoder(&cuerpo).Encode(successResponse{Success: true, Data: pagina}); err != nil {
+		sendError(w, "Error al obtener directorio de bodegas", http.StatusInternalServerError)
+		return
+	}
+
+	suma := sha256.Sum256(cuerpo.Bytes())
+	etag := `"` + hex.EncodeToString(suma[:16]) + `"`
+
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+	w.Header().Set("ETag", etag)
+	// CORS refleja el origen, así que la respuesta cacheada depende de él
+	w.Header().Add("Vary", "Origin")
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Write(cuerpo.Bytes())
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
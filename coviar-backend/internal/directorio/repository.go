@@ -0,0 +1,96 @@
+// RUTA: coviar-backend/internal/directorio/repository.go
+package directorio
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/platform/database"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// evaluacionVigente es una evaluación aprobada y vigente con los datos públicos de su
+// bodega. Solo se leen las columnas que el directorio puede mostrar.
+type evaluacionVigente struct {
+	IdEvaluacion     int    `json:"idEvaluacion"`
+	IdBodega         int    `json:"idBodega"`
+	IdNvSos          *int   `json:"idNvSos"`
+	FechaVencimiento string `json:"fecha_vencimiento"`
+	Bodega           struct {
+		Nombre         string  `json:"nombre"`
+		NombreFantasia string  `json:"nombre_fantasia"`
+		Ubicacion      string  `json:"ubicacion"`
+		Provincia      *string `json:"provincia"`
+	} `json:"bodega"`
+}
+
+// Repository lee los datos del directorio público
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindVigentes obtiene las evaluaciones aprobadas que no vencieron a la fecha dada, de
+// bodegas que no se excluyeron del directorio y, si se indica, de una provincia
+func (r *Repository) FindVigentes(provincia string, ahora time.Time) ([]evaluacionVigente, error) {
+	// !inner descarta las evaluaciones cuya bodega no cumple los filtros
+	return database.LeerPorTandas(func() *postgrest.FilterBuilder {
+		query := r.db.From("evaluacion").
+			Select(`"idEvaluacion","idBodega","idNvSos",fecha_vencimiento,bodega!inner(nombre,nombre_fantasia,ubicacion,provincia)`, "", false).
+			Eq("estado", domain.EstadoAprobada).
+			Gt("fecha_vencimiento", ahora.Format(time.RFC3339)).
+			Eq("bodega.excluir_directorio", "false")
+
+		if provincia != "" {
+			query = query.Eq("bodega.provincia", provincia)
+		}
+		return query
+	}, "idEvaluacion", func(evaluacion *evaluacionVigente) int { return evaluacion.IdEvaluacion })
+}
+
+// FindCertificados obtiene los certificados emitidos para las evaluaciones dadas
+func (r *Repository) FindCertificados(idsEvaluacion []int) ([]domain.Certificado, error) {
+	if len(idsEvaluacion) == 0 {
+		return nil, nil
+	}
+
+	data, _, err := r.db.From("certificado").
+		Select("*", "", false).
+		In("idEvaluacion", database.ToStrings(idsEvaluacion)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var certificados []domain.Certificado
+	if err := json.Unmarshal(data, &certificados); err != nil {
+		return nil, err
+	}
+
+	return certificados, nil
+}
+
+// FindNivelesSostenibilidad obtiene los niveles de sostenibilidad de todos los segmentos
+func (r *Repository) FindNivelesSostenibilidad() ([]domain.NivelSostenibilidad, error) {
+	data, _, err := r.db.From("nivel_sostenibilidad").
+		Select("*", "", false).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var niveles []domain.NivelSostenibilidad
+	if err := json.Unmarshal(data, &niveles); err != nil {
+		return nil, err
+	}
+
+	return niveles, nil
+}
@@ -0,0 +1,160 @@
+// RUTA: coviar-backend/internal/directorio/service.go
+package directorio
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/certificado"
+)
+
+// Límites de la paginación del directorio
+const (
+	LimitePredeterminado = 20
+	LimiteMaximo         = 100
+)
+
+// Filtro limita las bodegas del directorio. Los campos vacíos no filtran.
+type Filtro struct {
+	Provincia string
+	Nivel     string // nombre del nivel de sostenibilidad, sin distinguir mayúsculas
+	Pagina    int    // desde 1
+	Limite    int
+}
+
+// BodegaPublica son los únicos datos de una bodega que se publican en el directorio
+type BodegaPublica struct {
+	NombreFantasia    string  `json:"nombre_fantasia"`
+	Ubicacion         string  `json:"ubicacion"`
+	Provincia         *string `json:"provincia"`
+	Nivel             string  `json:"nivel"`
+	VigenteHasta      string  `json:"vigente_hasta"`
+	CodigoCertificado *string `json:"codigo_certificado"`
+	URLVerificacion   *string `json:"url_verificacion"`
+}
+
+// PaginaDirectorio es una página del directorio
+type PaginaDirectorio struct {
+	Bodegas []BodegaPublica `json:"bodegas"`
+	Pagina  int             `json:"pagina"`
+	Limite  int             `json:"limite"`
+	Total   int             `json:"total"`
+}
+
+// Service arma el directorio público de bodegas con una evaluación aprobada vigente
+type Service struct {
+	repo         *Repository
+	certificados *certificado.Service
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, certificadoService *certificado.Service) *Service {
+	return &Service{repo: repo, certificados: certificadoService}
+}
+
+// Listar devuelve una página del directorio, ordenado por nombre. Cada bodega aparece
+// una sola vez, con su última evaluación aprobada vigente.
+func (s *Service) Listar(filtro Filtro) (*PaginaDirectorio, error) {
+	if filtro.Pagina < 1 {
+		filtro.Pagina = 1
+	}
+	if filtro.Limite < 1 {
+		filtro.Limite = LimitePredeterminado
+	}
+	if filtro.Limite > LimiteMaximo {
+		filtro.Limite = LimiteMaximo
+	}
+
+	evaluaciones, err := s.repo.FindVigentes(filtro.Provincia, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Última evaluación vigente de cada bodega
+	ultimas := make(map[int]evaluacionVigente)
+	for _, ev := range evaluaciones {
+		if ev.IdNvSos == nil {
+			continue
+		}
+		if actual, ok := ultimas[ev.IdBodega]; !ok || ev.IdEvaluacion > actual.IdEvaluacion {
+			ultimas[ev.IdBodega] = ev
+		}
+	}
+
+	ids := make([]int, 0, len(ultimas))
+	for _, ev := range ultimas {
+		ids = append(ids, ev.IdEvaluacion)
+	}
+
+	certificados, err := s.repo.FindCertificados(ids)
+	if err != nil {
+		return nil, err
+	}
+	codigos := make(map[int]string)
+	for _, cert := range certificados {
+		codigos[cert.IdEvaluacion] = cert.Codigo
+	}
+
+	niveles, err := s.repo.FindNivelesSostenibilidad()
+	if err != nil {
+		return nil, err
+	}
+	nombresNivel := make(map[int]string)
+	for _, nivel := range niveles {
+		nombresNivel[nivel.IdNvSos] = nivel.Nombre
+	}
+
+	bodegas := make([]BodegaPublica, 0, len(ultimas))
+	for _, ev := range ultimas {
+		nivel := nombresNivel[*ev.IdNvSos]
+		if filtro.Nivel != "" && !strings.EqualFold(nivel, filtro.Nivel) {
+			continue
+		}
+
+		nombre := ev.Bodega.NombreFantasia
+		if nombre == "" {
+			nombre = ev.Bodega.Nombre
+		}
+
+		publica := BodegaPublica{
+			NombreFantasia: nombre,
+			Ubicacion:      ev.Bodega.Ubicacion,
+			Provincia:      ev.Bodega.Provincia,
+			Nivel:          nivel,
+			VigenteHasta:   ev.FechaVencimiento,
+		}
+		if vencimiento, err := time.Parse(time.RFC3339, ev.FechaVencimiento); err == nil {
+			publica.VigenteHasta = vencimiento.Format("2006-01-02")
+		}
+		if codigo, ok := codigos[ev.IdEvaluacion]; ok {
+			url := s.certificados.URLVerificacion(codigo)
+			publica.CodigoCertificado = &codigo
+			publica.URLVerificacion = &url
+		}
+
+		bodegas = append(bodegas, publica)
+	}
+
+	sort.Slice(bodegas, func(a, b int) bool {
+		return strings.ToLower(bodegas[a].NombreFantasia) < strings.ToLower(bodegas[b].NombreFantasia)
+	})
+
+	pagina := &PaginaDirectorio{
+		Bodegas: []BodegaPublica{},
+		Pagina:  filtro.Pagina,
+		Limite:  filtro.Limite,
+		Total:   len(bodegas),
+	}
+
+	desde := (filtro.Pagina - 1) * filtro.Limite
+	if desde < len(bodegas) {
+		hasta := desde + filtro.Limite
+		if hasta > len(bodegas) {
+			hasta = len(bodegas)
+		}
+		pagina.Bodegas = bodegas[desde:hasta]
+	}
+
+	return pagina, nil
+}
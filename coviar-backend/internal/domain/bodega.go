@@ -3,14 +3,29 @@ package domain
 
 // Bodega representa una bodega en el sistema COVIAR
 type Bodega struct {
-	IdBodega       int     `json:"idBodega"`
-	Cuit           int64   `json:"cuit"`
-	Inv            int     `json:"inv"`
-	ViñedosInv     int     `json:"viñedos_inv"`
-	Nombre         string  `json:"nombre"`
-	Ubicacion      string  `json:"ubicacion"`
-	ContactoEmail  string  `json:"contacto_email"`
-	RazonSocial    string  `json:"razon_social"`
-	NombreFantasia string  `json:"nombre_fantasia"`
-	CreatedAt      *string `json:"created_at"`
+	IdBodega         int     `json:"idBodega"`
+	Cuit             int64   `json:"cuit"`
+	Inv              int     `json:"inv"`
+	ViñedosInv       int     `json:"viñedos_inv"`
+	Nombre           string  `json:"nombre"`
+	Ubicacion        string  `json:"ubicacion"`
+	Provincia        *string `json:"provincia"`
+	Departamento     *string `json:"departamento"`
+	Distrito         *string `json:"distrito"`
+	ContactoEmail    string  `json:"contacto_email"`
+	ContactoTelefono *string `json:"contacto_telefono"`
+	RazonSocial      string  `json:"razon_social"`
+	NombreFantasia   string  `json:"nombre_fantasia"`
+	CreatedAt        *string `json:"created_at"`
+	UpdatedAt        *string `json:"updated_at"`
+
+	// Actividades que desarrolla la bodega (elaboración, enoturismo, exportación, ...)
+	Actividades []string `json:"actividades"`
+
+	// ExcluirDirectorio oculta la bodega del directorio público de bodegas certificadas
+	ExcluirDirectorio bool `json:"excluir_directorio"`
+
+	// ObservacionesInv son las advertencias del registro del INV al dar de alta la bodega
+	// (no se guardan)
+	ObservacionesInv []ObservacionInv `json:"observaciones_inv,omitempty"`
 }
@@ -0,0 +1,39 @@
+// RUTA: coviar-backend/internal/domain/catalogo.go
+package domain
+
+// Estados posibles de una versión del catálogo de indicadores
+const (
+	VersionBorrador  = "borrador"
+	VersionPublicada = "publicada"
+	VersionRetirada  = "retirada"
+)
+
+// VersionCatalogo representa una edición del cuestionario de autoevaluación
+type VersionCatalogo struct {
+	IdVersion        int     `json:"idVersion"`
+	Nombre           string  `json:"nombre"`
+	Estado           string  `json:"estado"`
+	FechaCreacion    *string `json:"fecha_creacion"`
+	FechaPublicacion *string `json:"fecha_publicacion"`
+	FechaRetiro      *string `json:"fecha_retiro"`
+	MesesVigencia    int     `json:"meses_vigencia"` // validez de las evaluaciones aprobadas con esta versión
+}
+
+// MesesVigenciaPredeterminado es la validez de una evaluación aprobada cuando su versión
+// del catálogo no define otra (o es anterior al catálogo versionado)
+const MesesVigenciaPredeterminado = 12
+
+// Capitulo agrupa indicadores dentro de una versión del catálogo
+type Capitulo struct {
+	IdCapitulo  int         `json:"idCapitulo"`
+	IdVersion   int         `json:"idVersion"`
+	Numero      int         `json:"numero"`
+	Nombre      string      `json:"nombre"`
+	Indicadores []Indicador `json:"indicadores,omitempty"`
+}
+
+// Catalogo es una versión completa con sus capítulos, indicadores y niveles
+type Catalogo struct {
+	Version   VersionCatalogo `json:"version"`
+	Capitulos []Capitulo      `json:"capitulos"`
+}
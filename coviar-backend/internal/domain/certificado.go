@@ -0,0 +1,32 @@
+// RUTA: coviar-backend/internal/domain/certificado.go
+package domain
+
+import "strconv"
+
+// Certificado acredita el nivel de sostenibilidad alcanzado por una bodega en una
+// evaluación aprobada. Los datos están firmados con la clave del servidor para que
+// cualquiera pueda verificar que no fueron alterados.
+type Certificado struct {
+	IdCertificado    int    `json:"idCertificado"`
+	Codigo           string `json:"codigo"`
+	IdEvaluacion     int    `json:"idEvaluacion"`
+	IdBodega         int    `json:"idBodega"`
+	NombreBodega     string `json:"nombre_bodega"`
+	Nivel            string `json:"nivel"` // nombre del nivel de sostenibilidad
+	FechaEmision     string `json:"fecha_emision"`
+	FechaVencimiento string `json:"fecha_vencimiento"`
+	Firma            string `json:"firma"` // Ed25519 en base64 sobre Contenido()
+}
+
+// Contenido es el texto que se firma: todos los datos que el certificado acredita,
+// en un orden fijo
+func (c *Certificado) Contenido() string {
+	return "COVIAR-CERTIFICADO-v1" +
+		"|" + c.Codigo +
+		"|" + strconv.Itoa(c.IdEvaluacion) +
+		"|" + strconv.Itoa(c.IdBodega) +
+		"|" + c.NombreBodega +
+		"|" + c.Nivel +
+		"|" + c.FechaEmision +
+		"|" + c.FechaVencimiento
+}
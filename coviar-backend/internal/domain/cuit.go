@@ -0,0 +1,72 @@
+// RUTA: coviar-backend/internal/domain/cuit.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// prefijosCuit son los tipos de CUIT asignados por AFIP: personas humanas (20, 23, 24,
+// 27) y personas jurídicas (30, 33, 34)
+var prefijosCuit = map[int64]bool{20: true, 23: true, 24: true, 27: true, 30: true, 33: true, 34: true}
+
+// pesosCuit son los multiplicadores del dígito verificador (módulo 11)
+var pesosCuit = []int64{5, 4, 3, 2, 7, 6, 5, 4, 3, 2}
+
+// ParseCuit convierte un CUIT escrito como XX-XXXXXXXX-X (con guiones, espacios o puntos
+// como separadores, o sin ellos) a su valor numérico. Solo controla que tenga 11 dígitos.
+func ParseCuit(texto string) (int64, bool) {
+	var cuit int64
+	digitos := 0
+	for _, r := range strings.TrimSpace(texto) {
+		switch {
+		case r >= '0' && r <= '9':
+			cuit = cuit*10 + int64(r-'0')
+			digitos++
+		case r == '-' || r == ' ' || r == '.':
+		default:
+			return 0, false
+		}
+	}
+	if digitos != 11 {
+		return 0, false
+	}
+	return cuit, true
+}
+
+// CuitValido indica si el CUIT tiene 11 dígitos, un prefijo de tipo válido y el dígito
+// verificador correcto
+func CuitValido(cuit int64) bool {
+	if cuit < 10000000000 || cuit > 99999999999 {
+		return false
+	}
+	if !prefijosCuit[cuit/1000000000] {
+		return false
+	}
+
+	texto := fmt.Sprintf("%011d", cuit)
+	var suma int64
+	for i, peso := range pesosCuit {
+		suma += int64(texto[i]-'0') * peso
+	}
+
+	verificador := 11 - suma%11
+	switch verificador {
+	case 11:
+		verificador = 0
+	case 10:
+		// AFIP no asigna estos números: usa el prefijo 23, 24 o 33 en su lugar
+		return false
+	}
+
+	return verificador == int64(texto[10]-'0')
+}
+
+// FormatearCuit muestra el CUIT como XX-XXXXXXXX-X
+func FormatearCuit(cuit int64) string {
+	texto := fmt.Sprintf("%011d", cuit)
+	if cuit <= 0 || len(texto) != 11 {
+		return ""
+	}
+	return texto[:2] + "-" + texto[2:10] + "-" + texto[10:]
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+func TestParseCuit(t *testing.T) {
+	tests := []struct {
+		nombre string
+		texto  string
+		cuit   int64
+		ok     bool
+	}{
+		{"con guiones", "30-71123456-6", 30711234566, true},
+		{"sin guiones", "30711234566", 30711234566, true},
+		{"con puntos y espacios", " 30.71123456 6 ", 30711234566, true},
+		{"corto", "30-7112345-6", 0, false},
+		{"largo", "30-711234567-6", 0, false},
+		{"con letras", "30-7112345A-6", 0, false},
+		{"vacío", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nombre, func(t *testing.T) {
+			cuit, ok := ParseCuit(tt.texto)
+			if cuit != tt.cuit || ok != tt.ok {
+				t.Fatalf("ParseCuit(%q) = %d, %v; se esperaba %d, %v", tt.texto, cuit, ok, tt.cuit, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCuitValido(t *testing.T) {
+	tests := []struct {
+		nombre string
+		cuit   int64
+		valido bool
+	}{
+		{"persona jurídica", 30711234566, true},
+		{"persona humana", 20123456786, true},
+		{"verificador cero", 20000000060, true},
+		{"verificador incorrecto", 30711234562, false},
+		{"verificador diez", 20000000010, false},
+		{"prefijo no asignado", 21123456782, false},
+		{"corto", 3071123456, false},
+		{"largo", 307112345660, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nombre, func(t *testing.T) {
+			if valido := CuitValido(tt.cuit); valido != tt.valido {
+				t.Fatalf("CuitValido(%d) = %v; se esperaba %v", tt.cuit, valido, tt.valido)
+			}
+		})
+	}
+}
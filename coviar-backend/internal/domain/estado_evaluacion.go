@@ -0,0 +1,108 @@
+// RUTA: coviar-backend/internal/domain/estado_evaluacion.go
+package domain
+
+// Estados posibles de una evaluación
+const (
+	EstadoBorrador   = "borrador"
+	EstadoEnviada    = "enviada"     // finalizada por la bodega, a la espera de un auditor
+	EstadoEnRevision = "en_revision" // asignada a un auditor
+	EstadoAprobada   = "aprobada"
+	EstadoRechazada  = "rechazada"
+	EstadoReabierta  = "reabierta" // rechazada y vuelta a abrir para corregirla
+	EstadoVencida    = "vencida"   // aprobada cuyo período de validez terminó
+)
+
+// RolSistema identifica los cambios de estado que hacen las tareas programadas
+const RolSistema = "sistema"
+
+// Transicion es un cambio de estado permitido de una evaluación junto con los roles
+// que pueden hacerlo
+type Transicion struct {
+	Desde string
+	Hacia string
+	Roles []string
+}
+
+// transicionesEvaluacion es la máquina de estados de una evaluación:
+//
+//	borrador ──> enviada ──> en_revision ──> aprobada ──> vencida
+//	                ^                   └──> rechazada ──> reabierta
+//	                └────────────────────────────────────────┘
+var transicionesEvaluacion = []Transicion{
+	{Desde: EstadoBorrador, Hacia: EstadoEnviada, Roles: []string{"bodega", "admin"}},
+	{Desde: EstadoReabierta, Hacia: EstadoEnviada, Roles: []string{"bodega", "admin"}},
+	{Desde: EstadoEnviada, Hacia: EstadoEnRevision, Roles: []string{"admin"}},
+	{Desde: EstadoEnRevision, Hacia: EstadoAprobada, Roles: []string{"auditor"}},
+	{Desde: EstadoEnRevision, Hacia: EstadoRechazada, Roles: []string{"auditor"}},
+	{Desde: EstadoRechazada, Hacia: EstadoReabierta, Roles: []string{"bodega", "admin"}},
+	{Desde: EstadoAprobada, Hacia: EstadoVencida, Roles: []string{RolSistema}},
+}
+
+// BuscarTransicion devuelve la transición de una evaluación entre dos estados, o nil si
+// la máquina de estados no la admite
+func BuscarTransicion(desde string, hacia string) *Transicion {
+	for i := range transicionesEvaluacion {
+		if transicionesEvaluacion[i].Desde == desde && transicionesEvaluacion[i].Hacia == hacia {
+			return &transicionesEvaluacion[i]
+		}
+	}
+	return nil
+}
+
+// PermiteRol indica si el rol puede realizar la transición
+func (t *Transicion) PermiteRol(rol string) bool {
+	for _, permitido := range t.Roles {
+		if permitido == rol {
+			return true
+		}
+	}
+	return false
+}
+
+// TransicionesDisponibles devuelve los estados a los que el rol puede llevar una
+// evaluación que está en el estado dado
+func TransicionesDisponibles(estado string, rol string) []string {
+	disponibles := []string{}
+	for i := range transicionesEvaluacion {
+		if transicionesEvaluacion[i].Desde == estado && transicionesEvaluacion[i].PermiteRol(rol) {
+			disponibles = append(disponibles, transicionesEvaluacion[i].Hacia)
+		}
+	}
+	return disponibles
+}
+
+// EvaluacionEditable indica si en ese estado la bodega puede modificar respuestas y evidencias
+func EvaluacionEditable(estado string) bool {
+	return estado == EstadoBorrador || estado == EstadoReabierta
+}
+
+// EvaluacionRenovable indica si a partir de una evaluación en ese estado se puede
+// iniciar su renovación
+func EvaluacionRenovable(estado string) bool {
+	return estado == EstadoAprobada || estado == EstadoVencida
+}
+
+// EvaluacionEnCurso indica si una evaluación en ese estado todavía no terminó su ciclo:
+// la bodega la está completando o espera la revisión del auditor. Las rechazadas no
+// cuentan mientras no se vuelvan a abrir.
+func EvaluacionEnCurso(estado string) bool {
+	switch estado {
+	case EstadoBorrador, EstadoEnviada, EstadoEnRevision, EstadoReabierta:
+		return true
+	default:
+		return false
+	}
+}
+
+// TransicionEvaluacion registra un cambio de estado de una evaluación: cuándo ocurrió
+// y quién lo hizo
+type TransicionEvaluacion struct {
+	IdTransicion   int     `json:"idTransicion"`
+	IdEvaluacion   int     `json:"idEvaluacion"`
+	EstadoAnterior string  `json:"estado_anterior"`
+	EstadoNuevo    string  `json:"estado_nuevo"`
+	IdUsuario      *int    `json:"idUsuario"` // nil en los cambios de las tareas programadas
+	Rol            string  `json:"rol"`
+	Comentario     *string `json:"comentario"`
+	Fecha          string  `json:"fecha"`
+}
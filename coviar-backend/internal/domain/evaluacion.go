@@ -1,14 +1,64 @@
 // RUTA: coviar-backend/internal/domain/evaluacion.go
 package domain
 
+// Veredictos del auditor sobre la respuesta a un indicador
+const (
+	VeredictoAceptado = "aceptado" // el nivel autodeclarado se mantiene
+	VeredictoAjustado = "ajustado" // el auditor asigna otro nivel, con comentario obligatorio
+)
+
+// Niveles mínimo y máximo que puede tomar la respuesta a un indicador
+const (
+	NivelMinimo = 0
+	NivelMaximo = 3
+)
+
 // Evaluacion representa una autoevaluación de sostenibilidad
 type Evaluacion struct {
 	IdEvaluacion    int     `json:"idEvaluacion"`
 	IdBodega        int     `json:"idBodega"`
 	IdSegmento      int     `json:"idSegmento"`
+	IdVersion       int     `json:"idVersion"` // versión del catálogo con la que se inició
 	FechaInicio     string  `json:"fecha_inicio"`
-	FechaCompletado *string `json:"fecha_completado"`
-	Estado          string  `json:"estado"`
+	FechaCompletado *string `json:"fecha_completado"` // fecha de envío a revisión
+	Estado          string  `json:"estado"`           // ver estado_evaluacion.go
 	PuntajeTotal    *int    `json:"puntaje_total"`
+	PuntajeMaximo   *int    `json:"puntaje_maximo"`
 	IdNvSos         *int    `json:"idNvSos"`
+
+	// Validez de la evaluación aprobada y evaluación de la que es renovación
+	FechaVencimiento     *string `json:"fecha_vencimiento"`
+	IdEvaluacionAnterior *int    `json:"idEvaluacionAnterior"`
+
+	// Revisión del auditor
+	IdAuditor          *int    `json:"idAuditor"`
+	FechaRevision      *string `json:"fecha_revision"`
+	ComentarioRevision *string `json:"comentario_revision"`
+}
+
+// Respuesta representa el nivel elegido para un indicador dentro de una evaluación
+type Respuesta struct {
+	IdRespuesta  int     `json:"idRespuesta"`
+	IdEvaluacion int     `json:"idEvaluacion"`
+	IdIndicador  int     `json:"idIndicador"`
+	Nivel        int     `json:"nivel"`
+	Version      int     `json:"version"` // se incrementa en cada guardado (control de concurrencia)
+	CreatedAt    *string `json:"created_at"`
+	UpdatedAt    *string `json:"updated_at"`
+
+	// Revisión del auditor. El nivel autodeclarado (Nivel) no se modifica: el ajustado
+	// se guarda aparte y es el que cuenta para el puntaje final.
+	Veredicto         *string `json:"veredicto"`
+	NivelAuditado     *int    `json:"nivel_auditado"`
+	ComentarioAuditor *string `json:"comentario_auditor"`
+	FechaAuditoria    *string `json:"fecha_auditoria"`
+}
+
+// NivelFinal devuelve el nivel que cuenta para el puntaje: el ajustado por el auditor
+// si lo hubo, o el autodeclarado
+func (r *Respuesta) NivelFinal() int {
+	if r.NivelAuditado != nil {
+		return *r.NivelAuditado
+	}
+	return r.Nivel
 }
@@ -0,0 +1,17 @@
+// RUTA: coviar-backend/internal/domain/evidencia.go
+package domain
+
+// Evidencia representa un archivo (PDF o imagen) adjunto a la respuesta de un indicador
+// como respaldo del nivel elegido
+type Evidencia struct {
+	IdEvidencia   int    `json:"idEvidencia"`
+	IdRespuesta   int    `json:"idRespuesta"`
+	IdEvaluacion  int    `json:"idEvaluacion"`
+	IdIndicador   int    `json:"idIndicador"`
+	NombreArchivo string `json:"nombre_archivo"`
+	TipoMime      string `json:"tipo_mime"`
+	Tamano        int64  `json:"tamano"`
+	Ruta          string `json:"-"` // ubicación dentro del almacenamiento, no se expone
+	IdUsuario     int    `json:"idUsuario"`
+	CreatedAt     string `json:"created_at"`
+}
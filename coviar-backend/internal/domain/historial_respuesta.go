@@ -0,0 +1,24 @@
+// RUTA: coviar-backend/internal/domain/historial_respuesta.go
+package domain
+
+// Orígenes de un cambio en la respuesta a un indicador
+const (
+	CambioAutoevaluacion = "autoevaluacion" // la bodega eligió otro nivel
+	CambioAuditoria      = "auditoria"      // el auditor aceptó o ajustó el nivel
+)
+
+// HistorialRespuesta es un registro inmutable de un cambio en la respuesta a un
+// indicador. NivelAnterior es nil cuando la respuesta se crea.
+type HistorialRespuesta struct {
+	IdHistorial   int     `json:"idHistorial"`
+	IdEvaluacion  int     `json:"idEvaluacion"`
+	IdIndicador   int     `json:"idIndicador"`
+	Tipo          string  `json:"tipo"`
+	NivelAnterior *int    `json:"nivel_anterior"`
+	NivelNuevo    int     `json:"nivel_nuevo"`
+	Veredicto     *string `json:"veredicto"` // solo en los cambios de auditoría
+	IdUsuario     int     `json:"idUsuario"`
+	Rol           string  `json:"rol"`
+	Comentario    *string `json:"comentario"`
+	Fecha         string  `json:"fecha"`
+}
@@ -1,11 +1,61 @@
 // RUTA: coviar-backend/internal/domain/indicador.go
 package domain
 
-// Indicador representa un indicador de sostenibilidad
+import (
+	"strconv"
+	"strings"
+)
+
+// Indicador representa un indicador de sostenibilidad.
+// Vigente indica que el indicador pertenece a la versión publicada del catálogo;
+// los indicadores de versiones retiradas se conservan para mostrar evaluaciones anteriores.
 type Indicador struct {
-	IdIndicador int     `json:"idIndicador"`
-	Codigo      string  `json:"codigo"`
-	Nombre      string  `json:"nombre"`
-	Descripcion *string `json:"descripcion"`
-	Vigente     bool    `json:"vigente"`
+	IdIndicador int              `json:"idIndicador"`
+	IdCapitulo  int              `json:"idCapitulo"`
+	Codigo      string           `json:"codigo"`
+	Nombre      string           `json:"nombre"`
+	Descripcion *string          `json:"descripcion"`
+	Vigente     bool             `json:"vigente"`
+	Niveles     []NivelIndicador `json:"niveles,omitempty"`
+}
+
+// NivelIndicador es la descripción de uno de los niveles (0 a 3) de un indicador
+type NivelIndicador struct {
+	IdNivelIndicador int    `json:"idNivelIndicador"`
+	IdIndicador      int    `json:"idIndicador"`
+	Nivel            int    `json:"nivel"`
+	Descripcion      string `json:"descripcion"`
+}
+
+// CompararCodigos compara códigos de indicador por sus partes numéricas ("1.2" < "1.10").
+// Devuelve un valor negativo, cero o positivo como strings.Compare.
+func CompararCodigos(a, b string) int {
+	partesA := strings.Split(a, ".")
+	partesB := strings.Split(b, ".")
+
+	for i := 0; i < len(partesA) && i < len(partesB); i++ {
+		numA, errA := strconv.Atoi(partesA[i])
+		numB, errB := strconv.Atoi(partesB[i])
+		if errA != nil || errB != nil {
+			if c := strings.Compare(partesA[i], partesB[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if numA != numB {
+			return numA - numB
+		}
+	}
+
+	return len(partesA) - len(partesB)
+}
+
+// NumeroCapitulo obtiene el número de capítulo de un código de indicador ("3.2" -> 3).
+// Devuelve 0 si el código no empieza con un número.
+func NumeroCapitulo(codigo string) int {
+	numero, err := strconv.Atoi(strings.SplitN(codigo, ".", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return numero
 }
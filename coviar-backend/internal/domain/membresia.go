@@ -0,0 +1,57 @@
+// RUTA: coviar-backend/internal/domain/membresia.go
+package domain
+
+// Roles de un usuario dentro de una bodega, de menor a mayor
+const (
+	RolBodegaLector      = "lector"      // consulta evaluaciones, planes y reportes
+	RolBodegaEditor      = "editor"      // además responde, envía evaluaciones y edita el plan
+	RolBodegaPropietario = "propietario" // además administra la bodega, sus miembros e invitaciones
+)
+
+// jerarquiaRolBodega ordena los roles de bodega: cada uno incluye los permisos de los anteriores
+var jerarquiaRolBodega = map[string]int{
+	RolBodegaLector:      1,
+	RolBodegaEditor:      2,
+	RolBodegaPropietario: 3,
+}
+
+// RolBodegaValido indica si el rol es uno de los roles de bodega
+func RolBodegaValido(rol string) bool {
+	_, ok := jerarquiaRolBodega[rol]
+	return ok
+}
+
+// RolBodegaAlcanza indica si el rol tiene al menos los permisos del rol mínimo
+func RolBodegaAlcanza(rol string, minimo string) bool {
+	return RolBodegaValido(rol) && jerarquiaRolBodega[rol] >= jerarquiaRolBodega[minimo]
+}
+
+// MiembroBodega vincula un usuario con una bodega
+type MiembroBodega struct {
+	IdMiembro int    `json:"idMiembro"`
+	IdUsuario int    `json:"idUsuario"`
+	IdBodega  int    `json:"idBodega"`
+	Rol       string `json:"rol"`
+	FechaAlta string `json:"fecha_alta"`
+
+	// Datos del usuario, al listar los miembros de una bodega
+	Usuario *struct {
+		Nombre   string `json:"nombre"`
+		Apellido string `json:"apellido"`
+		Email    string `json:"email"`
+	} `json:"usuario,omitempty"`
+}
+
+// InvitacionBodega invita a una persona, por email, a sumarse a una bodega con un rol.
+// Del token solo se guarda el hash: el token en claro viaja únicamente en el correo.
+type InvitacionBodega struct {
+	IdInvitacion       int     `json:"idInvitacion"`
+	IdBodega           int     `json:"idBodega"`
+	Email              string  `json:"email"`
+	Rol                string  `json:"rol"`
+	TokenHash          string  `json:"-"`
+	IdUsuarioInvitante int     `json:"idUsuarioInvitante"`
+	FechaCreacion      string  `json:"fecha_creacion"`
+	FechaExpiracion    string  `json:"fecha_expiracion"`
+	FechaAceptacion    *string `json:"fecha_aceptacion"`
+}
@@ -0,0 +1,16 @@
+// RUTA: coviar-backend/internal/domain/nivel_sostenibilidad.go
+package domain
+
+// NivelSostenibilidad representa una categoría de sostenibilidad definida por un rango de puntaje
+type NivelSostenibilidad struct {
+	IdNvSos       int    `json:"idNvSos"`
+	IdSegmento    *int   `json:"idSegmento"` // nil = umbral general para segmentos sin umbrales propios
+	Nombre        string `json:"nombre"`
+	PuntajeMinimo int    `json:"puntaje_minimo"`
+	PuntajeMaximo int    `json:"puntaje_maximo"`
+}
+
+// Contiene indica si el puntaje cae dentro del rango del nivel (ambos extremos incluidos)
+func (n *NivelSostenibilidad) Contiene(puntaje int) bool {
+	return puntaje >= n.PuntajeMinimo && puntaje <= n.PuntajeMaximo
+}
@@ -0,0 +1,46 @@
+// RUTA: coviar-backend/internal/domain/plan_mejora.go
+package domain
+
+// Estados de un ítem del plan de mejora
+const (
+	ItemPendiente  = "pendiente"
+	ItemEnCurso    = "en_curso"
+	ItemCompletado = "completado"
+)
+
+// Prioridades de un ítem del plan de mejora (1 = la más urgente)
+const (
+	PrioridadAlta  = 1
+	PrioridadMedia = 2
+	PrioridadBaja  = 3
+)
+
+// PlanMejora es el plan de acciones de una bodega para subir de nivel en los indicadores
+// en los que no alcanzó el máximo. Cada bodega tiene un único plan, que se regenera a
+// partir de su última evaluación finalizada.
+type PlanMejora struct {
+	IdPlan        int        `json:"idPlan"`
+	IdBodega      int        `json:"idBodega"`
+	IdEvaluacion  int        `json:"idEvaluacion"` // evaluación a partir de la cual se generó
+	FechaCreacion string     `json:"fecha_creacion"`
+	UpdatedAt     *string    `json:"updated_at"`
+	Items         []ItemPlan `json:"items,omitempty"`
+}
+
+// ItemPlan es una acción del plan de mejora: pasar un indicador del nivel actual al objetivo
+type ItemPlan struct {
+	IdItem        int     `json:"idItem"`
+	IdPlan        int     `json:"idPlan"`
+	IdIndicador   int     `json:"idIndicador"`
+	Codigo        string  `json:"codigo"`
+	Indicador     string  `json:"indicador"`
+	NivelActual   int     `json:"nivel_actual"`
+	NivelObjetivo int     `json:"nivel_objetivo"`
+	Accion        string  `json:"accion"` // descripción del nivel objetivo en el catálogo
+	Responsable   *string `json:"responsable"`
+	FechaLimite   *string `json:"fecha_limite"` // formato YYYY-MM-DD
+	Prioridad     int     `json:"prioridad"`
+	Orden         int     `json:"orden"` // posición dentro del plan al generarlo
+	Estado        string  `json:"estado"`
+	UpdatedAt     *string `json:"updated_at"`
+}
@@ -0,0 +1,13 @@
+// RUTA: coviar-backend/internal/domain/puntaje_capitulo.go
+package domain
+
+// PuntajeCapitulo es el puntaje obtenido por una evaluación en uno de los capítulos del catálogo
+type PuntajeCapitulo struct {
+	IdPuntajeCapitulo int     `json:"idPuntajeCapitulo"`
+	IdEvaluacion      int     `json:"idEvaluacion"`
+	NumeroCapitulo    int     `json:"numero_capitulo"`
+	Nombre            string  `json:"nombre"`
+	Puntaje           int     `json:"puntaje"`
+	PuntajeMaximo     int     `json:"puntaje_maximo"`
+	Porcentaje        float64 `json:"porcentaje"`
+}
@@ -0,0 +1,12 @@
+// RUTA: coviar-backend/internal/domain/recordatorio.go
+package domain
+
+// RecordatorioVencimiento registra el aviso enviado a una bodega antes de que venza su
+// evaluación aprobada, para no repetirlo
+type RecordatorioVencimiento struct {
+	IdRecordatorio int    `json:"idRecordatorio"`
+	IdEvaluacion   int    `json:"idEvaluacion"`
+	Dias           int    `json:"dias"` // anticipación del aviso (60, 30 o 7 días)
+	Destinatario   string `json:"destinatario"`
+	FechaEnvio     string `json:"fecha_envio"`
+}
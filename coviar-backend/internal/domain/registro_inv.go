@@ -0,0 +1,34 @@
+// RUTA: coviar-backend/internal/domain/registro_inv.go
+package domain
+
+// Tipos de establecimiento inscriptos en el Instituto Nacional de Vitivinicultura
+const (
+	TipoInvBodega = "bodega"
+	TipoInvVinedo = "viñedo"
+)
+
+// Gravedad de una observación del registro del INV
+const (
+	ObservacionAdvertencia = "advertencia" // el número no figura en el registro (puede estar desactualizado)
+	ObservacionError       = "error"       // el número figura a nombre de otro CUIT
+)
+
+// RegistroInv es un establecimiento del registro del INV importado por un administrador
+type RegistroInv struct {
+	Numero           int     `json:"numero"`
+	Tipo             string  `json:"tipo"`
+	Cuit             int64   `json:"cuit"`
+	RazonSocial      string  `json:"razon_social"`
+	Provincia        *string `json:"provincia"`
+	FechaImportacion string  `json:"fecha_importacion"`
+}
+
+// ObservacionInv es una diferencia entre los números de INV de una bodega y el registro
+type ObservacionInv struct {
+	IdBodega int    `json:"idBodega,omitempty"`
+	Bodega   string `json:"bodega,omitempty"`
+	Campo    string `json:"campo"` // inv o viñedos_inv
+	Numero   int    `json:"numero"`
+	Gravedad string `json:"gravedad"`
+	Motivo   string `json:"motivo"`
+}
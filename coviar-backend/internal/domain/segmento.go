@@ -9,3 +9,15 @@ type Segmento struct {
 	MaxTuristas *int    `json:"max_turistas"`
 	Descripcion *string `json:"descripcion"`
 }
+
+// Contiene indica si la cantidad de turistas anuales cae dentro del rango del segmento.
+// Un límite nulo se considera abierto.
+func (s *Segmento) Contiene(turistas int) bool {
+	if s.MinTuristas != nil && turistas < *s.MinTuristas {
+		return false
+	}
+	if s.MaxTuristas != nil && turistas > *s.MaxTuristas {
+		return false
+	}
+	return true
+}
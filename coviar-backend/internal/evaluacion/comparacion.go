@@ -0,0 +1,272 @@
+// RUTA: coviar-backend/internal/evaluacion/comparacion.go
+package evaluacion
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/puntaje"
+)
+
+// ErrEvaluacionesDeDistintaBodega se devuelve al comparar evaluaciones de bodegas diferentes
+var ErrEvaluacionesDeDistintaBodega = errors.New("solo se pueden comparar evaluaciones de la misma bodega")
+
+// Estados de un indicador al comparar dos evaluaciones
+const (
+	CambioMejora       = "mejora"
+	CambioRetroceso    = "retroceso"
+	CambioSinCambios   = "sin_cambios"
+	CambioSinRespuesta = "sin_respuesta" // falta la respuesta en alguna de las dos evaluaciones
+	CambioAgregado     = "agregado"      // solo aplica en la evaluación más reciente
+	CambioRetirado     = "retirado"      // solo aplicaba en la evaluación anterior
+)
+
+// Comparacion muestra lo que cambió entre dos evaluaciones de una misma bodega
+type Comparacion struct {
+	Anterior             ResumenEvaluacion   `json:"anterior"`
+	Actual               ResumenEvaluacion   `json:"actual"`
+	DeltaPuntaje         int                 `json:"delta_puntaje"`
+	DeltaPorcentaje      float64             `json:"delta_porcentaje"`
+	CambioSostenibilidad string              `json:"cambio_sostenibilidad"` // mejora, retroceso, sin_cambios o sin_respuesta
+	Capitulos            []CapituloComparado `json:"capitulos"`
+}
+
+// ResumenEvaluacion es el puntaje y el nivel de sostenibilidad de una de las evaluaciones comparadas
+type ResumenEvaluacion struct {
+	IdEvaluacion        int                         `json:"idEvaluacion"`
+	IdVersion           int                         `json:"idVersion"`
+	Estado              string                      `json:"estado"`
+	FechaInicio         string                      `json:"fecha_inicio"`
+	FechaCompletado     *string                     `json:"fecha_completado"`
+	Puntaje             int                         `json:"puntaje"`
+	PuntajeMaximo       int                         `json:"puntaje_maximo"`
+	Porcentaje          float64                     `json:"porcentaje"`
+	NivelSostenibilidad *domain.NivelSostenibilidad `json:"nivel_sostenibilidad"`
+}
+
+// CapituloComparado es la variación de puntaje de un capítulo
+type CapituloComparado struct {
+	Numero          int                  `json:"numero"`
+	Nombre          string               `json:"nombre"`
+	PuntajeAnterior int                  `json:"puntaje_anterior"`
+	PuntajeActual   int                  `json:"puntaje_actual"`
+	DeltaPuntaje    int                  `json:"delta_puntaje"`
+	Indicadores     []IndicadorComparado `json:"indicadores"`
+}
+
+// IndicadorComparado es la variación de nivel de un indicador. Los indicadores se
+// relacionan por código, ya que cada versión del catálogo tiene sus propios IDs. La
+// variación se calcula con los niveles finales (con el ajuste del auditor); los
+// autodeclarados se informan al lado.
+type IndicadorComparado struct {
+	Codigo                 string `json:"codigo"`
+	Nombre                 string `json:"nombre"`
+	NivelAnterior          *int   `json:"nivel_anterior"`
+	NivelActual            *int   `json:"nivel_actual"`
+	NivelAnteriorDeclarado *int   `json:"nivel_anterior_declarado"`
+	NivelActualDeclarado   *int   `json:"nivel_actual_declarado"`
+	DeltaNivel             *int   `json:"delta_nivel"`
+	Cambio                 string `json:"cambio"`
+}
+
+// Comparar compara dos evaluaciones de la misma bodega. El orden de los IDs no importa:
+// se toma como anterior la que se inició primero.
+func (s *Service) Comparar(idEvaluacion int, idOtra int) (*Comparacion, error) {
+	primera, err := s.GetByID(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+	segunda, err := s.GetByID(idOtra)
+	if err != nil {
+		return nil, err
+	}
+
+	if primera.IdBodega != segunda.IdBodega {
+		return nil, ErrEvaluacionesDeDistintaBodega
+	}
+
+	if segunda.FechaInicio < primera.FechaInicio ||
+		(segunda.FechaInicio == primera.FechaInicio && segunda.IdEvaluacion < primera.IdEvaluacion) {
+		primera, segunda = segunda, primera
+	}
+
+	anterior, err := s.cargarContenido(primera)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := s.cargarContenido(segunda)
+	if err != nil {
+		return nil, err
+	}
+
+	comparacion := &Comparacion{}
+	var ordenAnterior, ordenActual int
+	if comparacion.Anterior, ordenAnterior, err = s.resumir(anterior); err != nil {
+		return nil, err
+	}
+	if comparacion.Actual, ordenActual, err = s.resumir(actual); err != nil {
+		return nil, err
+	}
+
+	comparacion.DeltaPuntaje = comparacion.Actual.Puntaje - comparacion.Anterior.Puntaje
+	comparacion.DeltaPorcentaje = redondear(comparacion.Actual.Porcentaje - comparacion.Anterior.Porcentaje)
+	if ordenAnterior == 0 || ordenActual == 0 {
+		// Alguna de las dos todavía no tiene nivel de sostenibilidad asignado
+		comparacion.CambioSostenibilidad = CambioSinRespuesta
+	} else {
+		comparacion.CambioSostenibilidad = clasificarCambio(ordenActual - ordenAnterior)
+	}
+	comparacion.Capitulos = s.compararCapitulos(anterior, actual)
+
+	return comparacion, nil
+}
+
+// resumir calcula el puntaje de una evaluación y ubica su nivel de sostenibilidad en la
+// escala del segmento. Las evaluaciones finalizadas usan el puntaje guardado.
+func (s *Service) resumir(c *contenido) (ResumenEvaluacion, int, error) {
+	ev := c.evaluacion
+	resumen := ResumenEvaluacion{
+		IdEvaluacion:    ev.IdEvaluacion,
+		IdVersion:       ev.IdVersion,
+		Estado:          ev.Estado,
+		FechaInicio:     ev.FechaInicio,
+		FechaCompletado: ev.FechaCompletado,
+	}
+
+	for _, capitulo := range s.puntaje.CalcularCapitulos(c.capitulos, c.nivelesFinales()) {
+		resumen.Puntaje += capitulo.Puntaje
+		resumen.PuntajeMaximo += capitulo.PuntajeMaximo
+	}
+	if ev.PuntajeTotal != nil {
+		resumen.Puntaje = *ev.PuntajeTotal
+	}
+	if ev.PuntajeMaximo != nil {
+		resumen.PuntajeMaximo = *ev.PuntajeMaximo
+	}
+	resumen.Porcentaje = puntaje.Porcentaje(resumen.Puntaje, resumen.PuntajeMaximo)
+
+	if ev.IdNvSos == nil {
+		return resumen, 0, nil
+	}
+
+	nivel, orden, err := s.puntaje.UbicarNivel(ev.IdSegmento, *ev.IdNvSos)
+	if err != nil {
+		return resumen, 0, err
+	}
+	resumen.NivelSostenibilidad = nivel
+
+	return resumen, orden, nil
+}
+
+// compararCapitulos relaciona los capítulos por número y sus indicadores por código
+func (s *Service) compararCapitulos(anterior, actual *contenido) []CapituloComparado {
+	porNumero := make(map[int]*CapituloComparado)
+	var numeros []int
+
+	capituloComparado := func(capitulo domain.Capitulo) *CapituloComparado {
+		comparado, ok := porNumero[capitulo.Numero]
+		if !ok {
+			comparado = &CapituloComparado{Numero: capitulo.Numero}
+			porNumero[capitulo.Numero] = comparado
+			numeros = append(numeros, capitulo.Numero)
+		}
+		// El nombre de la versión más reciente tiene prioridad
+		if capitulo.Nombre != "" {
+			comparado.Nombre = capitulo.Nombre
+		}
+		return comparado
+	}
+
+	puntajesAnteriores := s.puntaje.CalcularCapitulos(anterior.capitulos, anterior.nivelesFinales())
+	puntajesActuales := s.puntaje.CalcularCapitulos(actual.capitulos, actual.nivelesFinales())
+
+	indicadoresAnteriores := make(map[string]domain.Indicador)
+	for i, capitulo := range anterior.capitulos {
+		comparado := capituloComparado(capitulo)
+		comparado.PuntajeAnterior = puntajesAnteriores[i].Puntaje
+		for _, indicador := range capitulo.Indicadores {
+			indicadoresAnteriores[indicador.Codigo] = indicador
+		}
+	}
+
+	vistos := make(map[string]bool)
+	for i, capitulo := range actual.capitulos {
+		comparado := capituloComparado(capitulo)
+		comparado.PuntajeActual = puntajesActuales[i].Puntaje
+
+		for _, indicador := range capitulo.Indicadores {
+			vistos[indicador.Codigo] = true
+			item := IndicadorComparado{
+				Codigo:               indicador.Codigo,
+				Nombre:               indicador.Nombre,
+				NivelActual:          actual.nivelFinal(indicador.IdIndicador),
+				NivelActualDeclarado: actual.nivel(indicador.IdIndicador),
+			}
+
+			previo, existia := indicadoresAnteriores[indicador.Codigo]
+			if !existia {
+				item.Cambio = CambioAgregado
+			} else {
+				item.NivelAnterior = anterior.nivelFinal(previo.IdIndicador)
+				item.NivelAnteriorDeclarado = anterior.nivel(previo.IdIndicador)
+				if item.NivelAnterior == nil || item.NivelActual == nil {
+					item.Cambio = CambioSinRespuesta
+				} else {
+					delta := *item.NivelActual - *item.NivelAnterior
+					item.DeltaNivel = &delta
+					item.Cambio = clasificarCambio(delta)
+				}
+			}
+
+			comparado.Indicadores = append(comparado.Indicadores, item)
+		}
+	}
+
+	// Indicadores que ya no aplican en la evaluación más reciente
+	for _, capitulo := range anterior.capitulos {
+		for _, indicador := range capitulo.Indicadores {
+			if vistos[indicador.Codigo] {
+				continue
+			}
+			comparado := porNumero[capitulo.Numero]
+			comparado.Indicadores = append(comparado.Indicadores, IndicadorComparado{
+				Codigo:                 indicador.Codigo,
+				Nombre:                 indicador.Nombre,
+				NivelAnterior:          anterior.nivelFinal(indicador.IdIndicador),
+				NivelAnteriorDeclarado: anterior.nivel(indicador.IdIndicador),
+				Cambio:                 CambioRetirado,
+			})
+		}
+	}
+
+	sort.Ints(numeros)
+	capitulos := make([]CapituloComparado, len(numeros))
+	for i, numero := range numeros {
+		comparado := porNumero[numero]
+		comparado.DeltaPuntaje = comparado.PuntajeActual - comparado.PuntajeAnterior
+		sort.SliceStable(comparado.Indicadores, func(a, b int) bool {
+			return domain.CompararCodigos(comparado.Indicadores[a].Codigo, comparado.Indicadores[b].Codigo) < 0
+		})
+		capitulos[i] = *comparado
+	}
+
+	return capitulos
+}
+
+func clasificarCambio(delta int) string {
+	switch {
+	case delta > 0:
+		return CambioMejora
+	case delta < 0:
+		return CambioRetroceso
+	default:
+		return CambioSinCambios
+	}
+}
+
+// redondear deja un porcentaje con un decimal
+func redondear(valor float64) float64 {
+	return math.Round(valor*10) / 10
+}
@@ -0,0 +1,233 @@
+// RUTA: coviar-backend/internal/evaluacion/contenido.go
+package evaluacion
+
+import (
+	"sort"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// contenido reúne lo necesario para analizar una evaluación: los indicadores que
+// aplican a su segmento agrupados por capítulo y el nivel respondido en cada uno
+type contenido struct {
+	evaluacion *domain.Evaluacion
+	capitulos  []domain.Capitulo // con sus indicadores aplicables, ordenados por número
+	niveles    map[int]int       // idIndicador -> nivel respondido (autodeclarado)
+	auditados  map[int]int       // idIndicador -> nivel ajustado por el auditor
+}
+
+// cargarContenido arma el contenido de una evaluación. Las evaluaciones anteriores al
+// catálogo versionado no tienen capítulos cargados, así que se agrupan por el prefijo
+// del código de indicador.
+func (s *Service) cargarContenido(evaluacion *domain.Evaluacion) (*contenido, error) {
+	aplicables, err := s.indicadoresAplicables(evaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	respuestas, err := s.repo.FindRespuestas(evaluacion.IdEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	niveles := make(map[int]int, len(respuestas))
+	auditados := make(map[int]int)
+	for _, respuesta := range respuestas {
+		niveles[respuesta.IdIndicador] = respuesta.Nivel
+		if respuesta.NivelAuditado != nil {
+			auditados[respuesta.IdIndicador] = *respuesta.NivelAuditado
+		}
+	}
+
+	capitulosPorId := make(map[int]domain.Capitulo)
+	if evaluacion.IdVersion != 0 {
+		catalogo, err := s.catalogo.GetCatalogo(evaluacion.IdVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, capitulo := range catalogo.Capitulos {
+			capitulo.Indicadores = nil
+			capitulosPorId[capitulo.IdCapitulo] = capitulo
+		}
+	}
+
+	porNumero := make(map[int]*domain.Capitulo)
+	var numeros []int
+	for _, indicador := range aplicables {
+		capitulo, ok := capitulosPorId[indicador.IdCapitulo]
+		if !ok {
+			capitulo = domain.Capitulo{Numero: domain.NumeroCapitulo(indicador.Codigo)}
+		}
+
+		agrupado, ok := porNumero[capitulo.Numero]
+		if !ok {
+			agrupado = &capitulo
+			porNumero[capitulo.Numero] = agrupado
+			numeros = append(numeros, capitulo.Numero)
+		}
+		agrupado.Indicadores = append(agrupado.Indicadores, indicador)
+	}
+
+	sort.Ints(numeros)
+	capitulos := make([]domain.Capitulo, len(numeros))
+	for i, numero := range numeros {
+		capitulos[i] = *porNumero[numero]
+	}
+
+	return &contenido{evaluacion: evaluacion, capitulos: capitulos, niveles: niveles, auditados: auditados}, nil
+}
+
+// nivelesFinales devuelve los niveles que cuentan para el puntaje: los ajustados por el
+// auditor reemplazan a los autodeclarados
+func (c *contenido) nivelesFinales() map[int]int {
+	if len(c.auditados) == 0 {
+		return c.niveles
+	}
+
+	finales := make(map[int]int, len(c.niveles))
+	for idIndicador, nivel := range c.niveles {
+		finales[idIndicador] = nivel
+	}
+	for idIndicador, nivel := range c.auditados {
+		finales[idIndicador] = nivel
+	}
+	return finales
+}
+
+// nivel devuelve el nivel respondido para un indicador, o nil si no tiene respuesta
+func (c *contenido) nivel(idIndicador int) *int {
+	nivel, ok := c.niveles[idIndicador]
+	if !ok {
+		return nil
+	}
+	return &nivel
+}
+
+// nivelFinal devuelve el nivel que cuenta para el puntaje de un indicador (el ajustado por
+// el auditor o, si no lo ajustó, el autodeclarado), o nil si no tiene respuesta
+func (c *contenido) nivelFinal(idIndicador int) *int {
+	if nivel := c.nivelAuditado(idIndicador); nivel != nil {
+		return nivel
+	}
+	return c.nivel(idIndicador)
+}
+
+// nivelAuditado devuelve el nivel ajustado por el auditor para un indicador, o nil si no lo ajustó
+func (c *contenido) nivelAuditado(idIndicador int) *int {
+	nivel, ok := c.auditados[idIndicador]
+	if !ok {
+		return nil
+	}
+	return &nivel
+}
+
+// cantidadRespondidos cuenta los indicadores aplicables que tienen respuesta
+func (c *contenido) cantidadRespondidos() int {
+	cantidad := 0
+	for _, capitulo := range c.capitulos {
+		for _, indicador := range capitulo.Indicadores {
+			if _, ok := c.niveles[indicador.IdIndicador]; ok {
+				cantidad++
+			}
+		}
+	}
+	return cantidad
+}
+
+// sinResponder devuelve los códigos de los indicadores aplicables que no tienen
+// respuesta, ordenados por capítulo
+func (c *contenido) sinResponder() []string {
+	var codigos []string
+	for _, capitulo := range c.capitulos {
+		for _, indicador := range capitulo.Indicadores {
+			if _, ok := c.niveles[indicador.IdIndicador]; !ok {
+				codigos = append(codigos, indicador.Codigo)
+			}
+		}
+	}
+	return codigos
+}
+
+// Brecha es un indicador aplicable en el que la evaluación no alcanzó el nivel máximo
+type Brecha struct {
+	Capitulo           domain.Capitulo  `json:"capitulo"` // sin sus indicadores
+	PorcentajeCapitulo float64          `json:"porcentaje_capitulo"`
+	Indicador          domain.Indicador `json:"indicador"` // con las descripciones de sus niveles
+	Nivel              int              `json:"nivel"`     // el final, con el ajuste del auditor; sin respuesta cuenta como 0
+}
+
+// GetBrechas obtiene los indicadores aplicables de una evaluación que quedaron por debajo
+// del nivel máximo, ordenados por capítulo y código
+func (s *Service) GetBrechas(id int) ([]Brecha, error) {
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	contenido, err := s.cargarContenido(evaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las mejoras se sugieren a partir de los niveles que aceptó el auditor
+	niveles := contenido.nivelesFinales()
+	puntajes := s.puntaje.CalcularCapitulos(contenido.capitulos, niveles)
+
+	var brechas []Brecha
+	for i, capitulo := range contenido.capitulos {
+		indicadores := capitulo.Indicadores
+		capitulo.Indicadores = nil
+		for _, indicador := range indicadores {
+			nivel := niveles[indicador.IdIndicador]
+			if nivel >= domain.NivelMaximo {
+				continue
+			}
+			brechas = append(brechas, Brecha{
+				Capitulo:           capitulo,
+				PorcentajeCapitulo: puntajes[i].Porcentaje,
+				Indicador:          indicador,
+				Nivel:              nivel,
+			})
+		}
+	}
+
+	return brechas, nil
+}
+
+// IndicadorEvaluado es un indicador aplicable de una evaluación junto con el nivel respondido
+type IndicadorEvaluado struct {
+	Capitulo      domain.Capitulo  `json:"capitulo"`       // sin sus indicadores
+	Indicador     domain.Indicador `json:"indicador"`      // con las descripciones de sus niveles
+	Nivel         *int             `json:"nivel"`          // autodeclarado; nil si quedó sin responder
+	NivelAuditado *int             `json:"nivel_auditado"` // nil si el auditor no lo ajustó
+}
+
+// GetIndicadoresEvaluados obtiene los indicadores aplicables de una evaluación con el
+// nivel respondido en cada uno, ordenados por capítulo y código
+func (s *Service) GetIndicadoresEvaluados(id int) ([]IndicadorEvaluado, error) {
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	contenido, err := s.cargarContenido(evaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluados []IndicadorEvaluado
+	for _, capitulo := range contenido.capitulos {
+		indicadores := capitulo.Indicadores
+		capitulo.Indicadores = nil
+		for _, indicador := range indicadores {
+			evaluados = append(evaluados, IndicadorEvaluado{
+				Capitulo:      capitulo,
+				Indicador:     indicador,
+				Nivel:         contenido.nivel(indicador.IdIndicador),
+				NivelAuditado: contenido.nivelAuditado(indicador.IdIndicador),
+			})
+		}
+	}
+
+	return evaluados, nil
+}
@@ -0,0 +1,642 @@
+// RUTA: coviar-backend/internal/evaluacion/handler.go
+package evaluacion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/auth"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/indicador"
+	"github.com/carli/coviar-backend/internal/segmento"
+)
+
+// Handler maneja las peticiones HTTP para Evaluacion
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// Route despacha las rutas que cuelgan de /api/evaluaciones/{id}
+func (h *Handler) Route(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extraer ID y sub-recurso de la URL
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/evaluaciones/"), "/")
+	parts := strings.Split(path, "/")
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		sendError(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.GetEvaluacion(w, r, id)
+	case len(parts) == 2 && parts[1] == "indicadores" && r.Method == http.MethodGet:
+		h.ListIndicadores(w, r, id)
+	case len(parts) == 2 && parts[1] == "respuestas" && r.Method == http.MethodGet:
+		h.ListRespuestas(w, r, id)
+	case len(parts) == 3 && parts[1] == "respuestas" && r.Method == http.MethodPut:
+		idIndicador, err := strconv.Atoi(parts[2])
+		if err != nil {
+			sendError(w, "ID de indicador inválido", http.StatusBadRequest)
+			return
+		}
+		h.SaveRespuesta(w, r, id, idIndicador)
+	case len(parts) == 2 && parts[1] == "finalizar" && r.Method == http.MethodPost:
+		h.FinalizarEvaluacion(w, r, id)
+	case len(parts) == 2 && parts[1] == "resultado" && r.Method == http.MethodGet:
+		h.GetResultado(w, r, id)
+	case len(parts) == 3 && parts[1] == "comparar" && r.Method == http.MethodGet:
+		idOtra, err := strconv.Atoi(parts[2])
+		if err != nil {
+			sendError(w, "ID de evaluación a comparar inválido", http.StatusBadRequest)
+			return
+		}
+		h.CompararEvaluaciones(w, r, id, idOtra)
+	case len(parts) == 2 && parts[1] == "reabrir" && r.Method == http.MethodPost:
+		h.ReabrirEvaluacion(w, r, id)
+	case len(parts) == 2 && parts[1] == "renovar" && r.Method == http.MethodPost:
+		h.RenovarEvaluacion(w, r, id)
+	case len(parts) == 2 && parts[1] == "historial" && r.Method == http.MethodGet:
+		h.ListHistorial(w, r, id)
+	case len(parts) == 2 && parts[1] == "transiciones" && r.Method == http.MethodGet:
+		h.ListTransiciones(w, r, id)
+	case len(parts) == 2 && parts[1] == "auditor" && r.Method == http.MethodPost:
+		h.AsignarAuditor(w, r, id)
+	case len(parts) == 3 && parts[1] == "revision" && r.Method == http.MethodPut:
+		idIndicador, err := strconv.Atoi(parts[2])
+		if err != nil {
+			sendError(w, "ID de indicador inválido", http.StatusBadRequest)
+			return
+		}
+		auth.RequireRole("auditor")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.RevisarRespuesta(w, r, id, idIndicador)
+		})).ServeHTTP(w, r)
+	case len(parts) == 2 && parts[1] == "revision" && r.Method == http.MethodPost:
+		h.DecidirRevision(w, r, id)
+	default:
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+// ListEvaluaciones maneja GET /api/evaluaciones?idBodega={id}
+func (h *Handler) ListEvaluaciones(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var evaluaciones []domain.Evaluacion
+	var err error
+
+	idBodega := 0
+	if idBodegaParam := r.URL.Query().Get("idBodega"); idBodegaParam != "" {
+		var convErr error
+		idBodega, convErr = strconv.Atoi(idBodegaParam)
+		if convErr != nil {
+			sendError(w, "ID de bodega inválido", http.StatusBadRequest)
+			return
+		}
+	}
+
+	actor, _ := actorDe(r)
+	switch {
+	case actor.Rol == "auditor":
+		// Los auditores solo ven las evaluaciones que tienen asignadas
+		evaluaciones, err = h.service.GetAsignadas(actor.IdUsuario, "")
+		if err == nil && idBodega > 0 {
+			evaluaciones = deBodega(evaluaciones, idBodega)
+		}
+	case idBodega > 0:
+		evaluaciones, err = h.service.GetByBodega(idBodega)
+	default:
+		evaluaciones, err = h.service.GetAll()
+	}
+
+	if err != nil {
+		log.Printf("Error al obtener evaluaciones: %v", err)
+		sendError(w, "Error al obtener evaluaciones", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, evaluaciones)
+}
+
+// RequireAuditorAsignado restringe a los auditores a las evaluaciones que tienen
+// asignadas. idEvaluacion obtiene de la petición el ID de la evaluación.
+func (h *Handler) RequireAuditorAsignado(idEvaluacion func(r *http.Request) (int, error)) func(http.Handler) http.Handler {
+	return h.requireAuditor(idEvaluacion, "ID de evaluación inválido", h.service.VerificarAuditor)
+}
+
+// RequireAuditorDeBodega restringe a los auditores a las bodegas de las que tienen
+// asignada alguna evaluación. Los demás roles pasan sin verificar.
+func (h *Handler) RequireAuditorDeBodega(idBodega func(r *http.Request) (int, error)) func(http.Handler) http.Handler {
+	return h.requireAuditor(idBodega, "ID de bodega inválido", h.service.VerificarAuditorDeBodega)
+}
+
+func (h *Handler) requireAuditor(resolver func(r *http.Request) (int, error), invalido string, verificar func(id int, actor Actor) error) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor, ok := actorDe(r)
+			if !ok || actor.Rol != "auditor" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+
+			id, err := resolver(r)
+			if err != nil {
+				sendError(w, invalido, http.StatusBadRequest)
+				return
+			}
+
+			if err := verificar(id, actor); err != nil {
+				sendServiceError(w, err, "Error al verificar permisos", http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CreateEvaluacion maneja POST /api/evaluaciones - Iniciar una evaluación para una bodega
+func (h *Handler) CreateEvaluacion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		IdBodega        int  `json:"idBodega"`
+		IdSegmento      int  `json:"idSegmento"`
+		TuristasAnuales *int `json:"turistas_anuales"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	evaluacion := domain.Evaluacion{
+		IdBodega:   body.IdBodega,
+		IdSegmento: body.IdSegmento,
+	}
+
+	if err := h.service.Iniciar(&evaluacion, body.TuristasAnuales); err != nil {
+		log.Printf("Error al iniciar evaluación: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	sendSuccess(w, evaluacion)
+}
+
+// evaluacionConTransiciones agrega a la evaluación los estados a los que puede llevarla
+// el usuario de la sesión, para que el frontend muestre solo las acciones posibles
+type evaluacionConTransiciones struct {
+	*domain.Evaluacion
+	TransicionesDisponibles []string `json:"transiciones_disponibles"`
+}
+
+// GetEvaluacion maneja GET /api/evaluaciones/{id}
+func (h *Handler) GetEvaluacion(w http.ResponseWriter, r *http.Request, id int) {
+	evaluacion, err := h.service.GetByID(id)
+	if err != nil {
+		log.Printf("Error al obtener evaluación: %v", err)
+		sendServiceError(w, err, "Error al obtener evaluación", http.StatusInternalServerError)
+		return
+	}
+
+	respuesta := evaluacionConTransiciones{Evaluacion: evaluacion, TransicionesDisponibles: []string{}}
+	if actor, ok := actorDe(r); ok {
+		respuesta.TransicionesDisponibles = domain.TransicionesDisponibles(evaluacion.Estado, actor.Rol)
+	}
+
+	sendSuccess(w, respuesta)
+}
+
+// ListIndicadores maneja GET /api/evaluaciones/{id}/indicadores - Indicadores aplicables al segmento
+func (h *Handler) ListIndicadores(w http.ResponseWriter, r *http.Request, id int) {
+	indicadores, err := h.service.GetIndicadores(id)
+	if err != nil {
+		log.Printf("Error al obtener indicadores: %v", err)
+		sendServiceError(w, err, "Error al obtener indicadores", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, indicadores)
+}
+
+// ListRespuestas maneja GET /api/evaluaciones/{id}/respuestas
+func (h *Handler) ListRespuestas(w http.ResponseWriter, r *http.Request, id int) {
+	respuestas, err := h.service.GetRespuestas(id)
+	if err != nil {
+		log.Printf("Error al obtener respuestas: %v", err)
+		sendServiceError(w, err, "Error al obtener respuestas", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, respuestas)
+}
+
+// SaveRespuesta maneja PUT /api/evaluaciones/{id}/respuestas/{idIndicador}
+// La versión sobre la que se trabajó se envía en el header If-Match (ETag devuelto
+// al guardar) o en el campo "version" del body. Sin versión, solo se admite crear la
+// respuesta. Si otra persona la modificó, responde 409 con el valor actual.
+func (h *Handler) SaveRespuesta(w http.ResponseWriter, r *http.Request, id int, idIndicador int) {
+	actor, ok := actorDe(r)
+	if !ok {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	var body struct {
+		Nivel      *int    `json:"nivel"`
+		Version    *int    `json:"version"`
+		Comentario *string `json:"comentario"` // opcional, queda en el historial
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Nivel == nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	versionEsperada := 0
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		version, err := parseETag(ifMatch)
+		if err != nil {
+			sendError(w, "Header If-Match inválido", http.StatusBadRequest)
+			return
+		}
+		versionEsperada = version
+	} else if body.Version != nil {
+		versionEsperada = *body.Version
+	}
+
+	respuesta := domain.Respuesta{
+		IdEvaluacion: id,
+		IdIndicador:  idIndicador,
+		Nivel:        *body.Nivel,
+	}
+
+	if err := h.service.GuardarRespuesta(&respuesta, versionEsperada, actor, comentarioOpcional(body.Comentario)); err != nil {
+		var conflicto *ConflictoVersionError
+		if errors.As(err, &conflicto) {
+			w.Header().Set("ETag", etag(conflicto.Actual.Version))
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(conflictResponse{
+				Error:   "conflicto",
+				Message: conflicto.Error(),
+				Data:    conflicto.Actual,
+			})
+			return
+		}
+
+		log.Printf("Error al guardar respuesta: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("ETag", etag(respuesta.Version))
+	sendSuccess(w, respuesta)
+}
+
+// FinalizarEvaluacion maneja POST /api/evaluaciones/{id}/finalizar - Envía la evaluación a revisión
+func (h *Handler) FinalizarEvaluacion(w http.ResponseWriter, r *http.Request, id int) {
+	actor, ok := actorDe(r)
+	if !ok {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	evaluacion, err := h.service.Finalizar(id, actor)
+	if err != nil {
+		log.Printf("Error al finalizar evaluación: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, evaluacion)
+}
+
+// ReabrirEvaluacion maneja POST /api/evaluaciones/{id}/reabrir - Vuelve a abrir una evaluación rechazada
+func (h *Handler) ReabrirEvaluacion(w http.ResponseWriter, r *http.Request, id int) {
+	actor, ok := actorDe(r)
+	if !ok {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	evaluacion, err := h.service.Reabrir(id, actor)
+	if err != nil {
+		log.Printf("Error al reabrir evaluación: %v", err)
+		sendServiceError(w, err, "Error al reabrir evaluación", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, evaluacion)
+}
+
+// RenovarEvaluacion maneja POST /api/evaluaciones/{id}/renovar - Inicia un borrador nuevo
+// precargado con las respuestas de una evaluación aprobada o vencida
+func (h *Handler) RenovarEvaluacion(w http.ResponseWriter, r *http.Request, id int) {
+	actor, ok := actorDe(r)
+	if !ok {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	if actor.Rol != "bodega" && actor.Rol != "admin" {
+		sendError(w, "Acceso denegado", http.StatusForbidden)
+		return
+	}
+
+	evaluacion, err := h.service.Renovar(id, actor)
+	if err != nil {
+		log.Printf("Error al renovar evaluación: %v", err)
+		sendServiceError(w, err, "Error al renovar evaluación", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	sendSuccess(w, evaluacion)
+}
+
+// ListHistorial maneja GET /api/evaluaciones/{id}/historial?idIndicador= - Línea de tiempo de
+// los cambios en las respuestas, de toda la evaluación o de un indicador
+func (h *Handler) ListHistorial(w http.ResponseWriter, r *http.Request, id int) {
+	idIndicador := 0
+	if param := r.URL.Query().Get("idIndicador"); param != "" {
+		valor, err := strconv.Atoi(param)
+		if err != nil || valor <= 0 {
+			sendError(w, "ID de indicador inválido", http.StatusBadRequest)
+			return
+		}
+		idIndicador = valor
+	}
+
+	historial, err := h.service.GetHistorial(id, idIndicador)
+	if err != nil {
+		log.Printf("Error al obtener historial: %v", err)
+		sendServiceError(w, err, "Error al obtener historial", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, historial)
+}
+
+// ListTransiciones maneja GET /api/evaluaciones/{id}/transiciones - Historial de cambios de estado
+func (h *Handler) ListTransiciones(w http.ResponseWriter, r *http.Request, id int) {
+	transiciones, err := h.service.GetTransiciones(id)
+	if err != nil {
+		log.Printf("Error al obtener transiciones: %v", err)
+		sendServiceError(w, err, "Error al obtener transiciones", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, transiciones)
+}
+
+// GetResultado maneja GET /api/evaluaciones/{id}/resultado - Puntaje desglosado por capítulo
+func (h *Handler) GetResultado(w http.ResponseWriter, r *http.Request, id int) {
+	resultado, err := h.service.GetResultado(id)
+	if err != nil {
+		log.Printf("Error al obtener resultado: %v", err)
+		sendServiceError(w, err, "Error al obtener resultado", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, resultado)
+}
+
+// CompararEvaluaciones maneja GET /api/evaluaciones/{id}/comparar/{idOtra}
+func (h *Handler) CompararEvaluaciones(w http.ResponseWriter, r *http.Request, id int, idOtra int) {
+	comparacion, err := h.service.Comparar(id, idOtra)
+	if err != nil {
+		log.Printf("Error al comparar evaluaciones: %v", err)
+		sendServiceError(w, err, "Error al comparar evaluaciones", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, comparacion)
+}
+
+// ListAsignadas maneja GET /api/auditor/evaluaciones?estado= - Evaluaciones asignadas al auditor de la sesión
+func (h *Handler) ListAsignadas(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	if !ok || claims == nil {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	evaluaciones, err := h.service.GetAsignadas(claims.IdUsuario, r.URL.Query().Get("estado"))
+	if err != nil {
+		log.Printf("Error al obtener evaluaciones asignadas: %v", err)
+		sendError(w, "Error al obtener evaluaciones asignadas", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, evaluaciones)
+}
+
+// AsignarAuditor maneja POST /api/evaluaciones/{id}/auditor - Asigna la evaluación a un auditor
+func (h *Handler) AsignarAuditor(w http.ResponseWriter, r *http.Request, id int) {
+	actor, ok := actorDe(r)
+	if !ok {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	var body struct {
+		IdAuditor int `json:"idAuditor"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.IdAuditor <= 0 {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	evaluacion, err := h.service.AsignarAuditor(id, body.IdAuditor, actor)
+	if err != nil {
+		log.Printf("Error al asignar auditor: %v", err)
+		sendServiceError(w, err, "Error al asignar auditor", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, evaluacion)
+}
+
+// RevisarRespuesta maneja PUT /api/evaluaciones/{id}/revision/{idIndicador} - Veredicto del
+// auditor sobre una respuesta: {"veredicto": "aceptado"} o {"veredicto": "ajustado", "nivel": 1, "comentario": "..."}
+func (h *Handler) RevisarRespuesta(w http.ResponseWriter, r *http.Request, id int, idIndicador int) {
+	actor, ok := actorDe(r)
+	if !ok {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	var revision RevisionRespuesta
+	if err := json.NewDecoder(r.Body).Decode(&revision); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	respuesta, err := h.service.RevisarRespuesta(id, idIndicador, actor, revision)
+	if err != nil {
+		log.Printf("Error al revisar respuesta: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, respuesta)
+}
+
+// DecidirRevision maneja POST /api/evaluaciones/{id}/revision - Cierra la revisión:
+// {"decision": "aprobar" | "rechazar", "comentario": "..."}
+func (h *Handler) DecidirRevision(w http.ResponseWriter, r *http.Request, id int) {
+	actor, ok := actorDe(r)
+	if !ok {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	var body struct {
+		Decision   string  `json:"decision"`
+		Comentario *string `json:"comentario"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	evaluacion, err := h.service.Decidir(id, actor, body.Decision, body.Comentario)
+	if err != nil {
+		log.Printf("Error al cerrar la revisión: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, evaluacion)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// conflictResponse acompaña el error 409 con el valor vigente en el servidor
+type conflictResponse struct {
+	Error   string      `json:"error"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// actorDe obtiene el usuario de la sesión que pide un cambio de estado
+func actorDe(r *http.Request) (Actor, bool) {
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	if !ok || claims == nil {
+		return Actor{}, false
+	}
+	return Actor{IdUsuario: claims.IdUsuario, Rol: claims.Rol}, true
+}
+
+// deBodega filtra las evaluaciones de una bodega
+func deBodega(evaluaciones []domain.Evaluacion, idBodega int) []domain.Evaluacion {
+	filtradas := []domain.Evaluacion{}
+	for _, ev := range evaluaciones {
+		if ev.IdBodega == idBodega {
+			filtradas = append(filtradas, ev)
+		}
+	}
+	return filtradas
+}
+
+// comentarioOpcional descarta los comentarios vacíos
+func comentarioOpcional(comentario *string) *string {
+	if comentario == nil {
+		return nil
+	}
+	texto := strings.TrimSpace(*comentario)
+	if texto == "" {
+		return nil
+	}
+	return &texto
+}
+
+// etag arma el ETag de una respuesta a partir de su versión
+func etag(version int) string {
+	return fmt.Sprintf("%q", strconv.Itoa(version))
+}
+
+// parseETag obtiene la versión de un header If-Match ("3", W/"3" o 3)
+func parseETag(valor string) (int, error) {
+	valor = strings.TrimPrefix(strings.TrimSpace(valor), "W/")
+	return strconv.Atoi(strings.Trim(valor, `"`))
+}
+
+// sendServiceError traduce los errores de negocio del servicio al código HTTP adecuado
+func sendServiceError(w http.ResponseWriter, err error, fallback string, fallbackStatus int) {
+	switch {
+	case errors.Is(err, ErrEvaluacionNoEncontrada), errors.Is(err, ErrIndicadorNoEncontrado), errors.Is(err, ErrRespuestaNoEncontrada),
+		errors.Is(err, segmento.ErrSegmentoNoEncontrado), errors.Is(err, indicador.ErrVersionNoEncontrada):
+		sendError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, indicador.ErrSinVersionPublicada):
+		sendError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrIndicadorNoAplicable), errors.Is(err, ErrEvaluacionesDeDistintaBodega), errors.Is(err, ErrRespuestasIncompletas):
+		sendError(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, ErrEvaluacionNoEditable), errors.Is(err, ErrTransicionInvalida), errors.Is(err, ErrNoEnRevision),
+		errors.Is(err, ErrRevisionIncompleta), errors.Is(err, ErrNoRenovable), errors.Is(err, ErrRenovacionEnCurso):
+		sendError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrAuditorInvalido):
+		sendError(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, ErrAuditorNoAsignado), errors.Is(err, ErrTransicionNoPermitida), errors.Is(err, ErrRolNoPermitido):
+		sendError(w, err.Error(), http.StatusForbidden)
+	default:
+		sendError(w, fallback, fallbackStatus)
+	}
+}
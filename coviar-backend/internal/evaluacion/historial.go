@@ -0,0 +1,53 @@
+// RUTA: coviar-backend/internal/evaluacion/historial.go
+package evaluacion
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// GetHistorial obtiene la línea de tiempo de cambios de las respuestas de una evaluación,
+// de la más antigua a la más reciente. Si idIndicador es mayor a 0, solo la de ese indicador.
+func (s *Service) GetHistorial(id int, idIndicador int) ([]domain.HistorialRespuesta, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindHistorial(id, idIndicador)
+}
+
+// registrarCambio agrega un cambio al historial de una respuesta. El historial es la
+// única constancia de quién cambió cada nivel, así que un error se devuelve al llamador.
+func (s *Service) registrarCambio(respuesta *domain.Respuesta, tipo string, nivelAnterior *int, actor Actor, comentario *string) error {
+	return s.registrarCambios([]domain.HistorialRespuesta{nuevoCambio(respuesta, tipo, nivelAnterior, actor, comentario)})
+}
+
+// registrarCambios agrega varios cambios al historial en una sola operación
+func (s *Service) registrarCambios(cambios []domain.HistorialRespuesta) error {
+	if err := s.repo.CreateHistorial(cambios); err != nil {
+		return fmt.Errorf("error al registrar el historial de respuestas: %w", err)
+	}
+	return nil
+}
+
+// nuevoCambio arma el registro de historial de un cambio en una respuesta
+func nuevoCambio(respuesta *domain.Respuesta, tipo string, nivelAnterior *int, actor Actor, comentario *string) domain.HistorialRespuesta {
+	cambio := domain.HistorialRespuesta{
+		IdEvaluacion:  respuesta.IdEvaluacion,
+		IdIndicador:   respuesta.IdIndicador,
+		Tipo:          tipo,
+		NivelAnterior: nivelAnterior,
+		NivelNuevo:    respuesta.Nivel,
+		IdUsuario:     actor.IdUsuario,
+		Rol:           actor.Rol,
+		Comentario:    comentario,
+		Fecha:         time.Now().Format(time.RFC3339),
+	}
+	if tipo == domain.CambioAuditoria {
+		cambio.NivelNuevo = respuesta.NivelFinal()
+		cambio.Veredicto = respuesta.Veredicto
+	}
+	return cambio
+}
@@ -0,0 +1,489 @@
+// RUTA: coviar-backend/internal/evaluacion/repository.go
+package evaluacion
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// ordenAscendente ordena los resultados de menor a mayor
+var ordenAscendente = postgrest.OrderOpts{Ascending: true}
+
+// Repository maneja el acceso a datos de Evaluacion y sus respuestas
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// Create crea una nueva evaluación. Devuelve ErrRenovacionEnCurso si la bodega ya tiene
+// otra en curso.
+func (r *Repository) Create(evaluacion *domain.Evaluacion) error {
+	evaluacionMap := map[string]interface{}{
+		"idBodega":             evaluacion.IdBodega,
+		"idSegmento":           evaluacion.IdSegmento,
+		"idVersion":            evaluacion.IdVersion,
+		"fecha_inicio":         evaluacion.FechaInicio,
+		"estado":               evaluacion.Estado,
+		"idEvaluacionAnterior": evaluacion.IdEvaluacionAnterior,
+	}
+
+	data, _, err := r.db.From("evaluacion").
+		Insert(evaluacionMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		// 23505: violación del índice evaluacion_una_en_curso, por un alta simultánea
+		if strings.Contains(err.Error(), "23505") || strings.Contains(err.Error(), "duplicate key") {
+			return ErrRenovacionEnCurso
+		}
+		return err
+	}
+
+	var result []domain.Evaluacion
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*evaluacion = result[0]
+	}
+
+	return nil
+}
+
+// FindAll obtiene todas las evaluaciones, de la más reciente a la más antigua
+func (r *Repository) FindAll() ([]domain.Evaluacion, error) {
+	data, _, err := r.db.From("evaluacion").
+		Select("*", "", false).
+		Order("fecha_inicio", nil).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluaciones []domain.Evaluacion
+	if err := json.Unmarshal(data, &evaluaciones); err != nil {
+		return nil, err
+	}
+
+	return evaluaciones, nil
+}
+
+// FindByBodega obtiene las evaluaciones de una bodega
+func (r *Repository) FindByBodega(idBodega int) ([]domain.Evaluacion, error) {
+	data, _, err := r.db.From("evaluacion").
+		Select("*", "", false).
+		Eq("idBodega", fmt.Sprintf("%d", idBodega)).
+		Order("fecha_inicio", nil).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluaciones []domain.Evaluacion
+	if err := json.Unmarshal(data, &evaluaciones); err != nil {
+		return nil, err
+	}
+
+	return evaluaciones, nil
+}
+
+// FindByAuditor obtiene las evaluaciones asignadas a un auditor, opcionalmente filtradas por estado
+func (r *Repository) FindByAuditor(idAuditor int, estado string) ([]domain.Evaluacion, error) {
+	query := r.db.From("evaluacion").
+		Select("*", "", false).
+		Eq("idAuditor", fmt.Sprintf("%d", idAuditor))
+
+	if estado != "" {
+		query = query.Eq("estado", estado)
+	}
+
+	data, _, err := query.
+		Order("fecha_completado", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluaciones []domain.Evaluacion
+	if err := json.Unmarshal(data, &evaluaciones); err != nil {
+		return nil, err
+	}
+
+	return evaluaciones, nil
+}
+
+// FindByID obtiene una evaluación por ID
+func (r *Repository) FindByID(id int) (*domain.Evaluacion, error) {
+	data, _, err := r.db.From("evaluacion").
+		Select("*", "", false).
+		Eq("idEvaluacion", fmt.Sprintf("%d", id)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluaciones []domain.Evaluacion
+	if err := json.Unmarshal(data, &evaluaciones); err != nil {
+		return nil, err
+	}
+
+	if len(evaluaciones) == 0 {
+		return nil, ErrEvaluacionNoEncontrada
+	}
+
+	return &evaluaciones[0], nil
+}
+
+// UpdateEstado actualiza el estado, la fecha de envío, el resultado, los datos de la
+// revisión y el vencimiento de una evaluación, solo si su estado sigue siendo
+// estadoAnterior. Devuelve false si otra petición cambió el estado entretanto. Si cambia
+// el estado, transicion indica quién lo hizo y se registra en la misma actualización.
+func (r *Repository) UpdateEstado(evaluacion *domain.Evaluacion, estadoAnterior string, transicion *domain.TransicionEvaluacion) (bool, error) {
+	updateMap := map[string]interface{}{
+		"estado":              evaluacion.Estado,
+		"fecha_completado":    evaluacion.FechaCompletado,
+		"puntaje_total":       evaluacion.PuntajeTotal,
+		"puntaje_maximo":      evaluacion.PuntajeMaximo,
+		"idNvSos":             evaluacion.IdNvSos,
+		"idAuditor":           evaluacion.IdAuditor,
+		"fecha_revision":      evaluacion.FechaRevision,
+		"comentario_revision": evaluacion.ComentarioRevision,
+		"fecha_vencimiento":   evaluacion.FechaVencimiento,
+	}
+	// El trigger de scripts/023 registra la transición con estos datos y los vacía
+	if transicion != nil {
+		updateMap["transicion_idUsuario"] = transicion.IdUsuario
+		updateMap["transicion_rol"] = transicion.Rol
+		updateMap["transicion_comentario"] = transicion.Comentario
+	}
+
+	data, _, err := r.db.From("evaluacion").
+		Update(updateMap, "", "").
+		Eq("idEvaluacion", fmt.Sprintf("%d", evaluacion.IdEvaluacion)).
+		Eq("estado", estadoAnterior).
+		Execute()
+
+	if err != nil {
+		return false, err
+	}
+
+	var result []domain.Evaluacion
+	if err := json.Unmarshal(data, &result); err != nil {
+		return false, err
+	}
+
+	return len(result) > 0, nil
+}
+
+// FindTransiciones obtiene el historial de cambios de estado de una evaluación, del más antiguo al más reciente
+func (r *Repository) FindTransiciones(idEvaluacion int) ([]domain.TransicionEvaluacion, error) {
+	data, _, err := r.db.From("transicion_evaluacion").
+		Select("*", "", false).
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion)).
+		Order("fecha", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var transiciones []domain.TransicionEvaluacion
+	if err := json.Unmarshal(data, &transiciones); err != nil {
+		return nil, err
+	}
+
+	return transiciones, nil
+}
+
+// FindRespuestas obtiene las respuestas de una evaluación
+func (r *Repository) FindRespuestas(idEvaluacion int) ([]domain.Respuesta, error) {
+	data, _, err := r.db.From("respuesta").
+		Select("*", "", false).
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion)).
+		Order("idIndicador", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var respuestas []domain.Respuesta
+	if err := json.Unmarshal(data, &respuestas); err != nil {
+		return nil, err
+	}
+
+	return respuestas, nil
+}
+
+// FindRespuesta obtiene la respuesta de un indicador dentro de una evaluación
+func (r *Repository) FindRespuesta(idEvaluacion int, idIndicador int) (*domain.Respuesta, error) {
+	data, _, err := r.db.From("respuesta").
+		Select("*", "", false).
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion)).
+		Eq("idIndicador", fmt.Sprintf("%d", idIndicador)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var respuestas []domain.Respuesta
+	if err := json.Unmarshal(data, &respuestas); err != nil {
+		return nil, err
+	}
+
+	if len(respuestas) == 0 {
+		return nil, ErrRespuestaNoEncontrada
+	}
+
+	return &respuestas[0], nil
+}
+
+// CreateRespuesta crea la primera respuesta de un indicador dentro de una evaluación.
+// Devuelve errRespuestaDuplicada si otra persona la creó antes.
+func (r *Repository) CreateRespuesta(respuesta *domain.Respuesta) error {
+	ahora := time.Now().Format(time.RFC3339)
+	respuestaMap := map[string]interface{}{
+		"idEvaluacion": respuesta.IdEvaluacion,
+		"idIndicador":  respuesta.IdIndicador,
+		"nivel":        respuesta.Nivel,
+		"version":      1,
+		"created_at":   ahora,
+		"updated_at":   ahora,
+	}
+
+	data, _, err := r.db.From("respuesta").
+		Insert(respuestaMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		// 23505: violación de la restricción UNIQUE ("idEvaluacion", "idIndicador")
+		if strings.Contains(err.Error(), "23505") || strings.Contains(err.Error(), "duplicate key") {
+			return errRespuestaDuplicada
+		}
+		return err
+	}
+
+	var result []domain.Respuesta
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*respuesta = result[0]
+	}
+
+	return nil
+}
+
+// UpdateRespuesta actualiza el nivel de una respuesta solo si su versión sigue siendo
+// la esperada, e incrementa la versión. Devuelve false si la respuesta cambió entretanto.
+func (r *Repository) UpdateRespuesta(respuesta *domain.Respuesta, versionEsperada int) (bool, error) {
+	updateMap := map[string]interface{}{
+		"nivel":      respuesta.Nivel,
+		"version":    versionEsperada + 1,
+		"updated_at": time.Now().Format(time.RFC3339),
+	}
+
+	data, _, err := r.db.From("respuesta").
+		Update(updateMap, "", "").
+		Eq("idEvaluacion", fmt.Sprintf("%d", respuesta.IdEvaluacion)).
+		Eq("idIndicador", fmt.Sprintf("%d", respuesta.IdIndicador)).
+		Eq("version", fmt.Sprintf("%d", versionEsperada)).
+		Execute()
+
+	if err != nil {
+		return false, err
+	}
+
+	var result []domain.Respuesta
+	if err := json.Unmarshal(data, &result); err != nil {
+		return false, err
+	}
+
+	if len(result) == 0 {
+		return false, nil
+	}
+
+	*respuesta = result[0]
+	return true, nil
+}
+
+// UpdateRevisionRespuesta guarda el veredicto del auditor sobre una respuesta. No cambia
+// la versión: el nivel autodeclarado queda intacto.
+func (r *Repository) UpdateRevisionRespuesta(respuesta *domain.Respuesta) error {
+	updateMap := map[string]interface{}{
+		"veredicto":          respuesta.Veredicto,
+		"nivel_auditado":     respuesta.NivelAuditado,
+		"comentario_auditor": respuesta.ComentarioAuditor,
+		"fecha_auditoria":    respuesta.FechaAuditoria,
+	}
+
+	data, _, err := r.db.From("respuesta").
+		Update(updateMap, "", "").
+		Eq("idRespuesta", fmt.Sprintf("%d", respuesta.IdRespuesta)).
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []domain.Respuesta
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*respuesta = result[0]
+	}
+
+	return nil
+}
+
+// LimpiarRevisionRespuestas borra los veredictos del auditor de todas las respuestas de
+// una evaluación, para que se vuelvan a revisar
+func (r *Repository) LimpiarRevisionRespuestas(idEvaluacion int) error {
+	updateMap := map[string]interface{}{
+		"veredicto":          nil,
+		"nivel_auditado":     nil,
+		"comentario_auditor": nil,
+		"fecha_auditoria":    nil,
+	}
+
+	_, _, err := r.db.From("respuesta").
+		Update(updateMap, "minimal", "").
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion)).
+		Execute()
+
+	return err
+}
+
+// CreateHistorial agrega cambios al historial de respuestas (la tabla no admite
+// modificaciones ni borrados)
+func (r *Repository) CreateHistorial(cambios []domain.HistorialRespuesta) error {
+	if len(cambios) == 0 {
+		return nil
+	}
+
+	filas := make([]map[string]interface{}, len(cambios))
+	for i, cambio := range cambios {
+		filas[i] = map[string]interface{}{
+			"idEvaluacion":   cambio.IdEvaluacion,
+			"idIndicador":    cambio.IdIndicador,
+			"tipo":           cambio.Tipo,
+			"nivel_anterior": cambio.NivelAnterior,
+			"nivel_nuevo":    cambio.NivelNuevo,
+			"veredicto":      cambio.Veredicto,
+			"idUsuario":      cambio.IdUsuario,
+			"rol":            cambio.Rol,
+			"comentario":     cambio.Comentario,
+			"fecha":          cambio.Fecha,
+		}
+	}
+
+	_, _, err := r.db.From("historial_respuesta").
+		Insert(filas, false, "", "minimal", "").
+		Execute()
+
+	return err
+}
+
+// FindHistorial obtiene el historial de respuestas de una evaluación en orden
+// cronológico, opcionalmente de un solo indicador
+func (r *Repository) FindHistorial(idEvaluacion int, idIndicador int) ([]domain.HistorialRespuesta, error) {
+	query := r.db.From("historial_respuesta").
+		Select("*", "", false).
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion))
+
+	if idIndicador > 0 {
+		query = query.Eq("idIndicador", fmt.Sprintf("%d", idIndicador))
+	}
+
+	data, _, err := query.
+		Order("fecha", &ordenAscendente).
+		Order("idHistorial", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var historial []domain.HistorialRespuesta
+	if err := json.Unmarshal(data, &historial); err != nil {
+		return nil, err
+	}
+
+	return historial, nil
+}
+
+// FindAprobadasHasta obtiene las evaluaciones aprobadas que vencen antes de la fecha dada
+func (r *Repository) FindAprobadasHasta(hasta time.Time) ([]domain.Evaluacion, error) {
+	data, _, err := r.db.From("evaluacion").
+		Select("*", "", false).
+		Eq("estado", domain.EstadoAprobada).
+		Lt("fecha_vencimiento", hasta.Format(time.RFC3339)).
+		Order("fecha_vencimiento", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluaciones []domain.Evaluacion
+	if err := json.Unmarshal(data, &evaluaciones); err != nil {
+		return nil, err
+	}
+
+	return evaluaciones, nil
+}
+
+// CreateRespuestas crea en bloque las respuestas de una evaluación nueva (versión 1) y
+// devuelve las filas creadas
+func (r *Repository) CreateRespuestas(respuestas []domain.Respuesta) ([]domain.Respuesta, error) {
+	if len(respuestas) == 0 {
+		return nil, nil
+	}
+
+	ahora := time.Now().Format(time.RFC3339)
+	filas := make([]map[string]interface{}, len(respuestas))
+	for i, respuesta := range respuestas {
+		filas[i] = map[string]interface{}{
+			"idEvaluacion": respuesta.IdEvaluacion,
+			"idIndicador":  respuesta.IdIndicador,
+			"nivel":        respuesta.Nivel,
+			"version":      1,
+			"created_at":   ahora,
+			"updated_at":   ahora,
+		}
+	}
+
+	data, _, err := r.db.From("respuesta").
+		Insert(filas, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []domain.Respuesta
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,84 @@
+// RUTA: coviar-backend/internal/evaluacion/resultado.go
+package evaluacion
+
+import (
+	"log"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/puntaje"
+)
+
+// ResultadoEvaluacion es el desglose del puntaje de una evaluación por capítulo. Capitulos
+// está ordenado por número y sirve directamente como serie de un gráfico de radar
+// (nombre como eje, porcentaje como valor sobre 100).
+type ResultadoEvaluacion struct {
+	IdEvaluacion        int                         `json:"idEvaluacion"`
+	Estado              string                      `json:"estado"`
+	Provisorio          bool                        `json:"provisorio"` // true mientras la bodega puede seguir editándola
+	PuntajeTotal        int                         `json:"puntaje_total"`
+	PuntajeMaximo       int                         `json:"puntaje_maximo"`
+	Porcentaje          float64                     `json:"porcentaje"`
+	NivelSostenibilidad *domain.NivelSostenibilidad `json:"nivel_sostenibilidad"`
+	Capitulos           []domain.PuntajeCapitulo    `json:"capitulos"`
+}
+
+// GetResultado obtiene el desglose por capítulo de una evaluación. Para las enviadas se
+// usan los puntajes guardados al enviarla (recalculados con los niveles auditados si se
+// aprobó); mientras es editable se calcula en el momento con las respuestas cargadas.
+func (s *Service) GetResultado(id int) (*ResultadoEvaluacion, error) {
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resultado := &ResultadoEvaluacion{
+		IdEvaluacion: evaluacion.IdEvaluacion,
+		Estado:       evaluacion.Estado,
+		Provisorio:   domain.EvaluacionEditable(evaluacion.Estado),
+	}
+
+	if !resultado.Provisorio && evaluacion.PuntajeTotal != nil && evaluacion.PuntajeMaximo != nil {
+		capitulos, err := s.puntaje.GetPuntajesCapitulo(evaluacion.IdEvaluacion)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(capitulos) > 0 {
+			resultado.PuntajeTotal = *evaluacion.PuntajeTotal
+			resultado.PuntajeMaximo = *evaluacion.PuntajeMaximo
+			resultado.Porcentaje = puntaje.Porcentaje(resultado.PuntajeTotal, resultado.PuntajeMaximo)
+			resultado.Capitulos = capitulos
+			if evaluacion.IdNvSos != nil {
+				if resultado.NivelSostenibilidad, _, err = s.puntaje.UbicarNivel(evaluacion.IdSegmento, *evaluacion.IdNvSos); err != nil {
+					return nil, err
+				}
+			}
+			return resultado, nil
+		}
+	}
+
+	contenido, err := s.cargarContenido(evaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	calculado, err := s.puntaje.Calcular(contenido.capitulos, contenido.nivelesFinales(), evaluacion.IdSegmento)
+	if err != nil {
+		return nil, err
+	}
+
+	// Evaluaciones finalizadas antes de que existiera el desglose: se guarda ahora
+	if !resultado.Provisorio {
+		if err := s.puntaje.GuardarPuntajesCapitulo(evaluacion.IdEvaluacion, calculado.Capitulos); err != nil {
+			log.Printf("Error al guardar puntajes por capítulo de la evaluación %d: %v", evaluacion.IdEvaluacion, err)
+		}
+	}
+
+	resultado.PuntajeTotal = calculado.PuntajeTotal
+	resultado.PuntajeMaximo = calculado.PuntajeMaximo
+	resultado.Porcentaje = puntaje.Porcentaje(calculado.PuntajeTotal, calculado.PuntajeMaximo)
+	resultado.NivelSostenibilidad = calculado.Nivel
+	resultado.Capitulos = calculado.Capitulos
+
+	return resultado, nil
+}
@@ -0,0 +1,295 @@
+// RUTA: coviar-backend/internal/evaluacion/revision.go
+package evaluacion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Errores de la revisión por auditores
+var (
+	ErrAuditorInvalido    = errors.New("el usuario no es un auditor activo")
+	ErrAuditorNoAsignado  = errors.New("la evaluación no está asignada a este auditor")
+	ErrNoEnRevision       = errors.New("la evaluación no está en revisión")
+	ErrRevisionIncompleta = errors.New("hay respuestas sin revisar")
+)
+
+// Decisiones con las que el auditor cierra la revisión
+const (
+	DecisionAprobar  = "aprobar"
+	DecisionRechazar = "rechazar"
+)
+
+// RevisionRespuesta es el veredicto del auditor sobre la respuesta a un indicador
+type RevisionRespuesta struct {
+	Veredicto  string  `json:"veredicto"`  // aceptado o ajustado
+	Nivel      *int    `json:"nivel"`      // requerido si se ajusta
+	Comentario *string `json:"comentario"` // requerido si se ajusta
+}
+
+// GetAsignadas obtiene las evaluaciones asignadas a un auditor (estado opcional)
+func (s *Service) GetAsignadas(idAuditor int, estado string) ([]domain.Evaluacion, error) {
+	if idAuditor <= 0 {
+		return nil, fmt.Errorf("ID de auditor inválido")
+	}
+
+	return s.repo.FindByAuditor(idAuditor, estado)
+}
+
+// VerificarAuditor comprueba que un auditor esté asignado a la evaluación. Los demás
+// roles no se restringen aquí (la membresía de la bodega se verifica aparte).
+func (s *Service) VerificarAuditor(id int, actor Actor) error {
+	if actor.Rol != "auditor" {
+		return nil
+	}
+
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if evaluacion.IdAuditor == nil || *evaluacion.IdAuditor != actor.IdUsuario {
+		return ErrAuditorNoAsignado
+	}
+
+	return nil
+}
+
+// VerificarAuditorDeBodega comprueba que un auditor tenga asignada alguna evaluación de
+// la bodega. Los demás roles no se restringen aquí.
+func (s *Service) VerificarAuditorDeBodega(idBodega int, actor Actor) error {
+	if actor.Rol != "auditor" {
+		return nil
+	}
+
+	asignadas, err := s.repo.FindByAuditor(actor.IdUsuario, "")
+	if err != nil {
+		return err
+	}
+	for _, evaluacion := range asignadas {
+		if evaluacion.IdBodega == idBodega {
+			return nil
+		}
+	}
+
+	return ErrAuditorNoAsignado
+}
+
+// AsignarAuditor asigna una evaluación enviada a un auditor y la pone en revisión.
+// También permite reasignar una evaluación que ya está en revisión.
+func (s *Service) AsignarAuditor(id int, idAuditor int, actor Actor) (*domain.Evaluacion, error) {
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	reasignacion := evaluacion.Estado == domain.EstadoEnRevision
+	if reasignacion {
+		// Reasignar no cambia el estado, pero lo pueden hacer los mismos roles que asignan
+		if !domain.BuscarTransicion(domain.EstadoEnviada, domain.EstadoEnRevision).PermiteRol(actor.Rol) {
+			return nil, ErrTransicionNoPermitida
+		}
+	} else if err := validarTransicion(evaluacion, domain.EstadoEnRevision, actor); err != nil {
+		return nil, err
+	}
+
+	auditor, err := s.usuarios.GetByID(idAuditor)
+	if err != nil || auditor.Rol != "auditor" || !auditor.Activo {
+		return nil, ErrAuditorInvalido
+	}
+
+	evaluacion.IdAuditor = &auditor.IdUsuario
+
+	if !reasignacion {
+		if err := s.transicionar(evaluacion, domain.EstadoEnRevision, actor, nil); err != nil {
+			return nil, err
+		}
+		return evaluacion, nil
+	}
+
+	actualizada, err := s.repo.UpdateEstado(evaluacion, domain.EstadoEnRevision, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !actualizada {
+		return nil, ErrNoEnRevision
+	}
+
+	return evaluacion, nil
+}
+
+// RevisarRespuesta guarda el veredicto del auditor asignado sobre la respuesta a un
+// indicador. Ajustar exige un nivel y un comentario; aceptar descarta un ajuste previo.
+// Cada veredicto queda en el historial de la respuesta.
+func (s *Service) RevisarRespuesta(id int, idIndicador int, actor Actor, revision RevisionRespuesta) (*domain.Respuesta, error) {
+	evaluacion, err := s.evaluacionEnRevision(id, actor.IdUsuario)
+	if err != nil {
+		return nil, err
+	}
+
+	respuesta, err := s.repo.FindRespuesta(evaluacion.IdEvaluacion, idIndicador)
+	if err != nil {
+		return nil, err
+	}
+	nivelAnterior := respuesta.NivelFinal()
+
+	var comentario *string
+	if revision.Comentario != nil {
+		if texto := strings.TrimSpace(*revision.Comentario); texto != "" {
+			comentario = &texto
+		}
+	}
+
+	switch revision.Veredicto {
+	case domain.VeredictoAceptado:
+		respuesta.NivelAuditado = nil
+	case domain.VeredictoAjustado:
+		if revision.Nivel == nil || *revision.Nivel < domain.NivelMinimo || *revision.Nivel > domain.NivelMaximo {
+			return nil, fmt.Errorf("el nivel debe estar entre %d y %d", domain.NivelMinimo, domain.NivelMaximo)
+		}
+		if comentario == nil {
+			return nil, fmt.Errorf("el comentario es obligatorio al ajustar el nivel")
+		}
+		nivel := *revision.Nivel
+		respuesta.NivelAuditado = &nivel
+	default:
+		return nil, fmt.Errorf("veredicto inválido: debe ser %q o %q", domain.VeredictoAceptado, domain.VeredictoAjustado)
+	}
+
+	ahora := time.Now().Format(time.RFC3339)
+	veredicto := revision.Veredicto
+	respuesta.Veredicto = &veredicto
+	respuesta.ComentarioAuditor = comentario
+	respuesta.FechaAuditoria = &ahora
+
+	if err := s.repo.UpdateRevisionRespuesta(respuesta); err != nil {
+		return nil, err
+	}
+
+	if err := s.registrarCambio(respuesta, domain.CambioAuditoria, &nivelAnterior, actor, comentario); err != nil {
+		return nil, err
+	}
+
+	return respuesta, nil
+}
+
+// Decidir cierra la revisión. Al aprobar, todas las respuestas deben tener veredicto, el
+// puntaje y el nivel de sostenibilidad se recalculan con los niveles auditados y se fija
+// el vencimiento. Al rechazar, el comentario es obligatorio.
+func (s *Service) Decidir(id int, actor Actor, decision string, comentario *string) (*domain.Evaluacion, error) {
+	var hacia string
+	switch decision {
+	case DecisionAprobar:
+		hacia = domain.EstadoAprobada
+	case DecisionRechazar:
+		hacia = domain.EstadoRechazada
+	default:
+		return nil, fmt.Errorf("decisión inválida: debe ser %q o %q", DecisionAprobar, DecisionRechazar)
+	}
+
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validarTransicion(evaluacion, hacia, actor); err != nil {
+		return nil, err
+	}
+	if evaluacion.IdAuditor == nil || *evaluacion.IdAuditor != actor.IdUsuario {
+		return nil, ErrAuditorNoAsignado
+	}
+
+	var texto *string
+	if comentario != nil {
+		if t := strings.TrimSpace(*comentario); t != "" {
+			texto = &t
+		}
+	}
+
+	var capitulos []domain.PuntajeCapitulo
+	if hacia == domain.EstadoAprobada {
+		capitulos, err = s.puntuarAuditada(evaluacion)
+		if err != nil {
+			return nil, err
+		}
+		vencimiento, err := s.calcularVencimiento(evaluacion)
+		if err != nil {
+			return nil, err
+		}
+		evaluacion.FechaVencimiento = &vencimiento
+	} else if texto == nil {
+		return nil, fmt.Errorf("el comentario es obligatorio al rechazar")
+	}
+
+	ahora := time.Now().Format(time.RFC3339)
+	evaluacion.FechaRevision = &ahora
+	evaluacion.ComentarioRevision = texto
+
+	if err := s.transicionar(evaluacion, hacia, actor, texto); err != nil {
+		return nil, err
+	}
+
+	if evaluacion.Estado == domain.EstadoAprobada {
+		if err := s.puntaje.GuardarPuntajesCapitulo(evaluacion.IdEvaluacion, capitulos); err != nil {
+			return nil, fmt.Errorf("error al guardar puntajes por capítulo: %w", err)
+		}
+		if s.alAprobar != nil {
+			s.alAprobar(evaluacion)
+		}
+	}
+
+	return evaluacion, nil
+}
+
+// puntuarAuditada verifica que todas las respuestas tengan veredicto y recalcula el
+// puntaje de la evaluación con los niveles auditados. Devuelve el desglose por capítulo.
+func (s *Service) puntuarAuditada(evaluacion *domain.Evaluacion) ([]domain.PuntajeCapitulo, error) {
+	respuestas, err := s.repo.FindRespuestas(evaluacion.IdEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+	for _, respuesta := range respuestas {
+		if respuesta.Veredicto == nil {
+			return nil, ErrRevisionIncompleta
+		}
+	}
+
+	contenido, err := s.cargarContenido(evaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	resultado, err := s.puntaje.Calcular(contenido.capitulos, contenido.nivelesFinales(), evaluacion.IdSegmento)
+	if err != nil {
+		return nil, fmt.Errorf("error al calcular puntaje: %w", err)
+	}
+
+	evaluacion.PuntajeTotal = &resultado.PuntajeTotal
+	evaluacion.PuntajeMaximo = &resultado.PuntajeMaximo
+	evaluacion.IdNvSos = nil
+	if resultado.Nivel != nil {
+		evaluacion.IdNvSos = &resultado.Nivel.IdNvSos
+	}
+
+	return resultado.Capitulos, nil
+}
+
+// evaluacionEnRevision obtiene una evaluación en revisión asignada al auditor dado
+func (s *Service) evaluacionEnRevision(id int, idAuditor int) (*domain.Evaluacion, error) {
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if evaluacion.Estado != domain.EstadoEnRevision {
+		return nil, ErrNoEnRevision
+	}
+	if evaluacion.IdAuditor == nil || *evaluacion.IdAuditor != idAuditor {
+		return nil, ErrAuditorNoAsignado
+	}
+
+	return evaluacion, nil
+}
@@ -0,0 +1,337 @@
+// RUTA: coviar-backend/internal/evaluacion/service.go
+package evaluacion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/indicador"
+	"github.com/carli/coviar-backend/internal/puntaje"
+	"github.com/carli/coviar-backend/internal/segmento"
+	"github.com/carli/coviar-backend/internal/usuario"
+)
+
+// Errores de negocio que el handler traduce a códigos HTTP
+var (
+	ErrEvaluacionNoEncontrada = errors.New("evaluación no encontrada")
+	ErrEvaluacionNoEditable   = errors.New("la evaluación ya fue enviada y no puede modificarse")
+	ErrIndicadorNoEncontrado  = errors.New("indicador no encontrado")
+	ErrIndicadorNoAplicable   = errors.New("el indicador no aplica al segmento de la evaluación")
+	ErrRespuestaNoEncontrada  = errors.New("el indicador todavía no tiene respuesta en la evaluación")
+	ErrConflictoVersion       = errors.New("la respuesta fue modificada por otra persona")
+	ErrRespuestasIncompletas  = errors.New("hay indicadores sin responder")
+	ErrRolNoPermitido         = errors.New("el rol no puede modificar las respuestas de la evaluación")
+
+	errRespuestaDuplicada = errors.New("la respuesta ya existe")
+)
+
+// ConflictoVersionError se devuelve cuando se intenta guardar una respuesta a partir de
+// una versión que ya no es la vigente. Incluye la respuesta actual del servidor.
+type ConflictoVersionError struct {
+	Actual *domain.Respuesta
+}
+
+func (e *ConflictoVersionError) Error() string {
+	return ErrConflictoVersion.Error()
+}
+
+// Unwrap permite usar errors.Is(err, ErrConflictoVersion)
+func (e *ConflictoVersionError) Unwrap() error {
+	return ErrConflictoVersion
+}
+
+// RespuestasIncompletasError se devuelve al finalizar una evaluación que tiene
+// indicadores aplicables sin responder. Incluye sus códigos.
+type RespuestasIncompletasError struct {
+	Codigos []string
+}
+
+func (e *RespuestasIncompletasError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrRespuestasIncompletas.Error(), strings.Join(e.Codigos, ", "))
+}
+
+// Unwrap permite usar errors.Is(err, ErrRespuestasIncompletas)
+func (e *RespuestasIncompletasError) Unwrap() error {
+	return ErrRespuestasIncompletas
+}
+
+// Service contiene la lógica de negocio de Evaluacion
+type Service struct {
+	repo      *Repository
+	puntaje   *puntaje.Service
+	segmentos *segmento.Service
+	catalogo  *indicador.Service
+	usuarios  *usuario.Service
+
+	// alAprobar se ejecuta cuando una evaluación queda aprobada (por ejemplo, para emitir
+	// su certificado). Se registra desde afuera para no crear dependencias circulares.
+	alAprobar func(*domain.Evaluacion)
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, puntajeService *puntaje.Service, segmentoService *segmento.Service, catalogoService *indicador.Service, usuarioService *usuario.Service) *Service {
+	return &Service{repo: repo, puntaje: puntajeService, segmentos: segmentoService, catalogo: catalogoService, usuarios: usuarioService}
+}
+
+// AlAprobar registra la función que se ejecuta cada vez que una evaluación queda aprobada
+func (s *Service) AlAprobar(f func(*domain.Evaluacion)) {
+	s.alAprobar = f
+}
+
+// Iniciar crea una evaluación en estado borrador para una bodega. Si no se indica
+// el segmento, se determina a partir de la cantidad de turistas anuales. La
+// evaluación queda asociada a la versión publicada del catálogo.
+func (s *Service) Iniciar(evaluacion *domain.Evaluacion, turistasAnuales *int) error {
+	if evaluacion.IdBodega <= 0 {
+		return fmt.Errorf("la bodega es requerida")
+	}
+
+	if evaluacion.IdSegmento <= 0 {
+		if turistasAnuales == nil {
+			return fmt.Errorf("el segmento o la cantidad de turistas anuales es requerido")
+		}
+		seg, err := s.segmentos.GetByTuristas(*turistasAnuales)
+		if err != nil {
+			return err
+		}
+		evaluacion.IdSegmento = seg.IdSegmento
+	} else if _, err := s.segmentos.GetByID(evaluacion.IdSegmento); err != nil {
+		return err
+	}
+
+	version, err := s.catalogo.GetVersionPublicada()
+	if err != nil {
+		return err
+	}
+
+	evaluacion.IdVersion = version.IdVersion
+	evaluacion.FechaInicio = time.Now().Format(time.RFC3339)
+	evaluacion.Estado = domain.EstadoBorrador
+	evaluacion.FechaCompletado = nil
+	evaluacion.PuntajeTotal = nil
+	evaluacion.PuntajeMaximo = nil
+	evaluacion.IdNvSos = nil
+
+	return s.repo.Create(evaluacion)
+}
+
+// GetAll obtiene todas las evaluaciones
+func (s *Service) GetAll() ([]domain.Evaluacion, error) {
+	return s.repo.FindAll()
+}
+
+// GetByBodega obtiene las evaluaciones de una bodega
+func (s *Service) GetByBodega(idBodega int) ([]domain.Evaluacion, error) {
+	if idBodega <= 0 {
+		return nil, fmt.Errorf("ID de bodega inválido")
+	}
+
+	return s.repo.FindByBodega(idBodega)
+}
+
+// GetByID obtiene una evaluación por ID
+func (s *Service) GetByID(id int) (*domain.Evaluacion, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+
+	return s.repo.FindByID(id)
+}
+
+// GetIndicadores obtiene, con sus niveles, los indicadores de la versión del catálogo
+// de la evaluación que aplican a su segmento
+func (s *Service) GetIndicadores(idEvaluacion int) ([]domain.Indicador, error) {
+	evaluacion, err := s.GetByID(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.indicadoresAplicables(evaluacion)
+}
+
+// GetRespuestas obtiene las respuestas cargadas en una evaluación
+func (s *Service) GetRespuestas(idEvaluacion int) ([]domain.Respuesta, error) {
+	if _, err := s.GetByID(idEvaluacion); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindRespuestas(idEvaluacion)
+}
+
+// GetRespuesta obtiene la respuesta de un indicador dentro de una evaluación
+func (s *Service) GetRespuesta(idEvaluacion int, idIndicador int) (*domain.Respuesta, error) {
+	if idEvaluacion <= 0 || idIndicador <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+
+	return s.repo.FindRespuesta(idEvaluacion, idIndicador)
+}
+
+// GuardarRespuesta crea o actualiza el nivel elegido para un indicador. versionEsperada
+// es la versión de la respuesta sobre la que trabajó el cliente (0 si todavía no existía):
+// si otra persona la modificó entretanto se devuelve un *ConflictoVersionError con el
+// valor actual en lugar de sobrescribirla. Cada cambio de nivel queda en el historial
+// con el actor y el comentario opcional.
+func (s *Service) GuardarRespuesta(respuesta *domain.Respuesta, versionEsperada int, actor Actor, comentario *string) error {
+	if respuesta.Nivel < domain.NivelMinimo || respuesta.Nivel > domain.NivelMaximo {
+		return fmt.Errorf("el nivel debe estar entre %d y %d", domain.NivelMinimo, domain.NivelMaximo)
+	}
+	if versionEsperada < 0 {
+		return fmt.Errorf("versión inválida")
+	}
+	// Los auditores no autodeclaran niveles: sus ajustes van por la revisión
+	if actor.Rol != "bodega" && actor.Rol != "admin" {
+		return ErrRolNoPermitido
+	}
+
+	evaluacion, err := s.GetByID(respuesta.IdEvaluacion)
+	if err != nil {
+		return err
+	}
+
+	if !domain.EvaluacionEditable(evaluacion.Estado) {
+		return ErrEvaluacionNoEditable
+	}
+
+	aplicables, err := s.indicadoresAplicables(evaluacion)
+	if err != nil {
+		return err
+	}
+	if !contieneIndicador(aplicables, respuesta.IdIndicador) {
+		return ErrIndicadorNoAplicable
+	}
+
+	if versionEsperada == 0 {
+		err := s.repo.CreateRespuesta(respuesta)
+		if errors.Is(err, errRespuestaDuplicada) {
+			return s.conflicto(respuesta)
+		}
+		if err != nil {
+			return err
+		}
+		return s.registrarCambio(respuesta, domain.CambioAutoevaluacion, nil, actor, comentario)
+	}
+
+	// El nivel anterior es el de la versión sobre la que trabajó el cliente; si la
+	// versión ya cambió, la actualización condicional falla y no se usa
+	anterior, err := s.repo.FindRespuesta(respuesta.IdEvaluacion, respuesta.IdIndicador)
+	if err != nil {
+		return err
+	}
+	if anterior.Version != versionEsperada {
+		return &ConflictoVersionError{Actual: anterior}
+	}
+
+	actualizada, err := s.repo.UpdateRespuesta(respuesta, versionEsperada)
+	if err != nil {
+		return err
+	}
+	if !actualizada {
+		return s.conflicto(respuesta)
+	}
+
+	if anterior.Nivel != respuesta.Nivel {
+		return s.registrarCambio(respuesta, domain.CambioAutoevaluacion, &anterior.Nivel, actor, comentario)
+	}
+
+	return nil
+}
+
+// conflicto arma el error de versión con la respuesta que hay actualmente en el servidor
+func (s *Service) conflicto(respuesta *domain.Respuesta) error {
+	actual, err := s.repo.FindRespuesta(respuesta.IdEvaluacion, respuesta.IdIndicador)
+	if err != nil {
+		return err
+	}
+
+	return &ConflictoVersionError{Actual: actual}
+}
+
+// Finalizar envía la evaluación a revisión: deja de admitir cambios y se calcula en el
+// servidor su puntaje total y su nivel de sostenibilidad con los niveles autodeclarados.
+// Si es un reenvío tras un rechazo, se descartan los veredictos de la revisión anterior.
+func (s *Service) Finalizar(id int, actor Actor) (*domain.Evaluacion, error) {
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validarTransicion(evaluacion, domain.EstadoEnviada, actor); err != nil {
+		return nil, err
+	}
+	reenvio := evaluacion.Estado == domain.EstadoReabierta
+
+	contenido, err := s.cargarContenido(evaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo puntúan las respuestas a indicadores que aplican al segmento, y tienen que
+	// estar todas: un indicador sin responder contaría como 0 sin que nadie lo decida
+	if contenido.cantidadRespondidos() == 0 {
+		return nil, fmt.Errorf("la evaluación no tiene respuestas")
+	}
+	if faltantes := contenido.sinResponder(); len(faltantes) > 0 {
+		return nil, &RespuestasIncompletasError{Codigos: faltantes}
+	}
+
+	resultado, err := s.puntaje.Calcular(contenido.capitulos, contenido.niveles, evaluacion.IdSegmento)
+	if err != nil {
+		return nil, fmt.Errorf("error al calcular puntaje: %w", err)
+	}
+
+	fechaCompletado := time.Now().Format(time.RFC3339)
+	evaluacion.FechaCompletado = &fechaCompletado
+	evaluacion.PuntajeTotal = &resultado.PuntajeTotal
+	evaluacion.PuntajeMaximo = &resultado.PuntajeMaximo
+	evaluacion.IdNvSos = nil
+	if resultado.Nivel != nil {
+		evaluacion.IdNvSos = &resultado.Nivel.IdNvSos
+	}
+	evaluacion.FechaRevision = nil
+	evaluacion.ComentarioRevision = nil
+
+	if err := s.transicionar(evaluacion, domain.EstadoEnviada, actor, nil); err != nil {
+		return nil, err
+	}
+
+	if reenvio {
+		if err := s.repo.LimpiarRevisionRespuestas(evaluacion.IdEvaluacion); err != nil {
+			return nil, fmt.Errorf("error al descartar la revisión anterior: %w", err)
+		}
+	}
+
+	if err := s.puntaje.GuardarPuntajesCapitulo(evaluacion.IdEvaluacion, resultado.Capitulos); err != nil {
+		return nil, fmt.Errorf("error al guardar puntajes por capítulo: %w", err)
+	}
+
+	return evaluacion, nil
+}
+
+// indicadoresAplicables obtiene los indicadores de la versión con la que se inició la
+// evaluación (vigentes o no) filtrados por las reglas de su segmento
+func (s *Service) indicadoresAplicables(evaluacion *domain.Evaluacion) ([]domain.Indicador, error) {
+	// Evaluaciones anteriores al catálogo versionado: se usan los indicadores vigentes
+	if evaluacion.IdVersion == 0 {
+		return s.segmentos.GetIndicadoresAplicables(evaluacion.IdSegmento)
+	}
+
+	indicadores, err := s.catalogo.GetIndicadoresVersion(evaluacion.IdVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.segmentos.FiltrarAplicables(evaluacion.IdSegmento, indicadores)
+}
+
+func contieneIndicador(indicadores []domain.Indicador, idIndicador int) bool {
+	for _, indicador := range indicadores {
+		if indicador.IdIndicador == idIndicador {
+			return true
+		}
+	}
+	return false
+}
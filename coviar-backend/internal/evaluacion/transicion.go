@@ -0,0 +1,112 @@
+// RUTA: coviar-backend/internal/evaluacion/transicion.go
+package evaluacion
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Errores de la máquina de estados
+var (
+	ErrTransicionInvalida    = errors.New("transición de estado inválida")
+	ErrTransicionNoPermitida = errors.New("el rol no puede realizar este cambio de estado")
+)
+
+// TransicionInvalidaError se devuelve cuando la evaluación no puede pasar del estado
+// en el que está al pedido (por ejemplo, aprobar una evaluación en borrador)
+type TransicionInvalidaError struct {
+	Desde string
+	Hacia string
+}
+
+func (e *TransicionInvalidaError) Error() string {
+	return fmt.Sprintf("la evaluación está en estado %q y no puede pasar a %q", e.Desde, e.Hacia)
+}
+
+// Unwrap permite usar errors.Is(err, ErrTransicionInvalida)
+func (e *TransicionInvalidaError) Unwrap() error {
+	return ErrTransicionInvalida
+}
+
+// Actor es el usuario que pide un cambio de estado
+type Actor struct {
+	IdUsuario int
+	Rol       string
+}
+
+// actorSistema es el actor de los cambios que hacen las tareas programadas
+var actorSistema = Actor{Rol: domain.RolSistema}
+
+// GetTransiciones obtiene el historial de cambios de estado de una evaluación
+func (s *Service) GetTransiciones(id int) ([]domain.TransicionEvaluacion, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindTransiciones(id)
+}
+
+// Reabrir vuelve a abrir una evaluación rechazada para que la bodega la corrija. Los
+// veredictos del auditor se conservan como referencia hasta que se vuelva a enviar.
+func (s *Service) Reabrir(id int, actor Actor) (*domain.Evaluacion, error) {
+	evaluacion, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.transicionar(evaluacion, domain.EstadoReabierta, actor, nil); err != nil {
+		return nil, err
+	}
+
+	return evaluacion, nil
+}
+
+// validarTransicion verifica que la máquina de estados admita el cambio y que el rol
+// del actor pueda hacerlo
+func validarTransicion(evaluacion *domain.Evaluacion, hacia string, actor Actor) error {
+	transicion := domain.BuscarTransicion(evaluacion.Estado, hacia)
+	if transicion == nil {
+		return &TransicionInvalidaError{Desde: evaluacion.Estado, Hacia: hacia}
+	}
+	if !transicion.PermiteRol(actor.Rol) {
+		return ErrTransicionNoPermitida
+	}
+	return nil
+}
+
+// transicionar valida y guarda el cambio de estado junto con el resto de los datos
+// modificados de la evaluación, y lo registra en el historial. El guardado solo se
+// aplica si nadie cambió el estado entretanto.
+func (s *Service) transicionar(evaluacion *domain.Evaluacion, hacia string, actor Actor, comentario *string) error {
+	if err := validarTransicion(evaluacion, hacia, actor); err != nil {
+		return err
+	}
+
+	desde := evaluacion.Estado
+	evaluacion.Estado = hacia
+
+	// La transición se registra junto con el cambio de estado (scripts/023): si no se
+	// puede registrar, el estado no cambia
+	registro := &domain.TransicionEvaluacion{Rol: actor.Rol, Comentario: comentario}
+	if actor.IdUsuario > 0 {
+		registro.IdUsuario = &actor.IdUsuario
+	}
+
+	actualizada, err := s.repo.UpdateEstado(evaluacion, desde, registro)
+	if err != nil {
+		evaluacion.Estado = desde
+		return err
+	}
+	if !actualizada {
+		actual, err := s.GetByID(evaluacion.IdEvaluacion)
+		if err != nil {
+			return err
+		}
+		*evaluacion = *actual
+		return &TransicionInvalidaError{Desde: actual.Estado, Hacia: hacia}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// RUTA: coviar-backend/internal/evaluacion/vigencia.go
+package evaluacion
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Errores de la renovación de evaluaciones
+var (
+	ErrNoRenovable       = errors.New("solo se pueden renovar evaluaciones aprobadas o vencidas")
+	ErrRenovacionEnCurso = errors.New("la bodega ya tiene una evaluación en curso")
+)
+
+// VencerEvaluaciones marca como vencidas las evaluaciones aprobadas cuyo período de
+// validez terminó antes de ahora. Devuelve las que se vencieron.
+func (s *Service) VencerEvaluaciones(ahora time.Time) ([]domain.Evaluacion, error) {
+	aprobadas, err := s.repo.FindAprobadasHasta(ahora)
+	if err != nil {
+		return nil, err
+	}
+
+	var vencidas []domain.Evaluacion
+	for i := range aprobadas {
+		evaluacion := &aprobadas[i]
+		err := s.transicionar(evaluacion, domain.EstadoVencida, actorSistema, nil)
+		if errors.Is(err, ErrTransicionInvalida) {
+			continue // cambió de estado entretanto
+		}
+		if err != nil {
+			return vencidas, err
+		}
+		vencidas = append(vencidas, *evaluacion)
+	}
+
+	return vencidas, nil
+}
+
+// GetPorVencer obtiene las evaluaciones aprobadas que vencen antes de la fecha dada
+func (s *Service) GetPorVencer(hasta time.Time) ([]domain.Evaluacion, error) {
+	return s.repo.FindAprobadasHasta(hasta)
+}
+
+// Renovar inicia un borrador nuevo para la bodega de una evaluación aprobada o vencida,
+// con la versión publicada del catálogo y precargado con los niveles finales (los
+// auditados, si los hubo) de los indicadores que se mantienen, identificados por código.
+func (s *Service) Renovar(id int, actor Actor) (*domain.Evaluacion, error) {
+	anterior, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.EvaluacionRenovable(anterior.Estado) {
+		return nil, ErrNoRenovable
+	}
+
+	evaluaciones, err := s.repo.FindByBodega(anterior.IdBodega)
+	if err != nil {
+		return nil, err
+	}
+	// El índice evaluacion_una_en_curso (scripts/025) cubre las renovaciones simultáneas
+	for _, evaluacion := range evaluaciones {
+		if domain.EvaluacionEnCurso(evaluacion.Estado) {
+			return nil, ErrRenovacionEnCurso
+		}
+	}
+
+	contenidoAnterior, err := s.cargarContenido(anterior)
+	if err != nil {
+		return nil, err
+	}
+
+	nivelesAnteriores := contenidoAnterior.nivelesFinales()
+	nivelPorCodigo := make(map[string]int)
+	for _, capitulo := range contenidoAnterior.capitulos {
+		for _, indicador := range capitulo.Indicadores {
+			if nivel, ok := nivelesAnteriores[indicador.IdIndicador]; ok {
+				nivelPorCodigo[indicador.Codigo] = nivel
+			}
+		}
+	}
+
+	nueva := &domain.Evaluacion{
+		IdBodega:             anterior.IdBodega,
+		IdSegmento:           anterior.IdSegmento,
+		IdEvaluacionAnterior: &anterior.IdEvaluacion,
+	}
+	if err := s.Iniciar(nueva, nil); err != nil {
+		return nil, err
+	}
+
+	aplicables, err := s.indicadoresAplicables(nueva)
+	if err != nil {
+		return nil, err
+	}
+
+	var respuestas []domain.Respuesta
+	for _, indicador := range aplicables {
+		if nivel, ok := nivelPorCodigo[indicador.Codigo]; ok {
+			respuestas = append(respuestas, domain.Respuesta{
+				IdEvaluacion: nueva.IdEvaluacion,
+				IdIndicador:  indicador.IdIndicador,
+				Nivel:        nivel,
+			})
+		}
+	}
+
+	creadas, err := s.repo.CreateRespuestas(respuestas)
+	if err != nil {
+		return nil, fmt.Errorf("error al precargar las respuestas: %w", err)
+	}
+
+	comentario := fmt.Sprintf("Precargada desde la evaluación %d", anterior.IdEvaluacion)
+	cambios := make([]domain.HistorialRespuesta, len(creadas))
+	for i := range creadas {
+		cambios[i] = nuevoCambio(&creadas[i], domain.CambioAutoevaluacion, nil, actor, &comentario)
+	}
+	if err := s.registrarCambios(cambios); err != nil {
+		return nil, err
+	}
+
+	return nueva, nil
+}
+
+// calcularVencimiento devuelve la fecha en que vence una evaluación aprobada: la de envío
+// más los meses de validez de su versión del catálogo
+func (s *Service) calcularVencimiento(evaluacion *domain.Evaluacion) (string, error) {
+	meses := domain.MesesVigenciaPredeterminado
+	if evaluacion.IdVersion != 0 {
+		version, err := s.catalogo.GetVersion(evaluacion.IdVersion)
+		if err != nil {
+			return "", err
+		}
+		if version.MesesVigencia > 0 {
+			meses = version.MesesVigencia
+		}
+	}
+
+	desde := time.Now()
+	if evaluacion.FechaCompletado != nil {
+		if fecha, err := time.Parse(time.RFC3339, *evaluacion.FechaCompletado); err == nil {
+			desde = fecha
+		}
+	}
+
+	return desde.AddDate(0, meses, 0).Format(time.RFC3339), nil
+}
@@ -0,0 +1,313 @@
+// RUTA: coviar-backend/internal/evidencia/handler.go
+package evidencia
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/auth"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+	"github.com/carli/coviar-backend/internal/membresia"
+)
+
+// Handler maneja las peticiones HTTP para Evidencia
+type Handler struct {
+	service  *Service
+	miembros *membresia.Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service, membresiaService *membresia.Service) *Handler {
+	return &Handler{service: service, miembros: membresiaService}
+}
+
+// ListEvidencias maneja GET /api/evidencias?idEvaluacion={id}&idIndicador={id}
+func (h *Handler) ListEvidencias(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !puedeAcceder(r) {
+		sendError(w, "No tiene permiso para ver evidencias", http.StatusForbidden)
+		return
+	}
+
+	idEvaluacion, err := strconv.Atoi(r.URL.Query().Get("idEvaluacion"))
+	if err != nil || idEvaluacion <= 0 {
+		sendError(w, "ID de evaluación inválido", http.StatusBadRequest)
+		return
+	}
+
+	idIndicador := 0
+	if param := r.URL.Query().Get("idIndicador"); param != "" {
+		idIndicador, err = strconv.Atoi(param)
+		if err != nil {
+			sendError(w, "ID de indicador inválido", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := h.verificarMiembro(r, idEvaluacion, domain.RolBodegaLector); err != nil {
+		sendServiceError(w, err, "Error al verificar permisos", http.StatusInternalServerError)
+		return
+	}
+
+	evidencias, err := h.service.GetByEvaluacion(idEvaluacion, idIndicador)
+	if err != nil {
+		log.Printf("Error al obtener evidencias: %v", err)
+		sendServiceError(w, err, "Error al obtener evidencias", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, evidencias)
+}
+
+// UploadEvidencia maneja POST /api/evidencias (multipart/form-data con los campos
+// idEvaluacion, idIndicador y archivo)
+func (h *Handler) UploadEvidencia(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	if !ok || claims == nil {
+		sendError(w, "No autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	if !puedeModificar(r) {
+		sendError(w, "No tiene permiso para subir evidencias", http.StatusForbidden)
+		return
+	}
+
+	// Margen de 1 MB para los demás campos del formulario
+	r.Body = http.MaxBytesReader(w, r.Body, TamanoMaximo+(1<<20))
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			sendError(w, ErrArchivoMuyGrande.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		sendError(w, "Formulario inválido", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	idEvaluacion, err := strconv.Atoi(r.FormValue("idEvaluacion"))
+	if err != nil {
+		sendError(w, "ID de evaluación inválido", http.StatusBadRequest)
+		return
+	}
+	idIndicador, err := strconv.Atoi(r.FormValue("idIndicador"))
+	if err != nil {
+		sendError(w, "ID de indicador inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verificarMiembro(r, idEvaluacion, domain.RolBodegaEditor); err != nil {
+		sendServiceError(w, err, "Error al verificar permisos", http.StatusInternalServerError)
+		return
+	}
+
+	archivo, cabecera, err := r.FormFile("archivo")
+	if err != nil {
+		sendError(w, "El archivo es requerido", http.StatusBadRequest)
+		return
+	}
+	defer archivo.Close()
+
+	evidencia, err := h.service.Subir(idEvaluacion, idIndicador, claims.IdUsuario, cabecera.Filename, cabecera.Size, archivo)
+	if err != nil {
+		log.Printf("Error al subir evidencia: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	sendSuccess(w, evidencia)
+}
+
+// Route despacha las rutas que cuelgan de /api/evidencias/{id}
+func (h *Handler) Route(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/evidencias/"), "/")
+
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		sendError(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.DownloadEvidencia(w, r, id)
+	case http.MethodDelete:
+		h.DeleteEvidencia(w, r, id)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+// DownloadEvidencia maneja GET /api/evidencias/{id} - Descarga el archivo
+func (h *Handler) DownloadEvidencia(w http.ResponseWriter, r *http.Request, id int) {
+	if !puedeAcceder(r) {
+		w.Header().Set("Content-Type", "application/json")
+		sendError(w, "No tiene permiso para ver evidencias", http.StatusForbidden)
+		return
+	}
+
+	if err := h.verificarMiembroEvidencia(r, id, domain.RolBodegaLector); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		sendServiceError(w, err, "Error al verificar permisos", http.StatusInternalServerError)
+		return
+	}
+
+	evidencia, contenido, err := h.service.Abrir(id)
+	if err != nil {
+		log.Printf("Error al abrir evidencia: %v", err)
+		w.Header().Set("Content-Type", "application/json")
+		sendServiceError(w, err, "Error al obtener evidencia", http.StatusInternalServerError)
+		return
+	}
+	defer contenido.Close()
+
+	w.Header().Set("Content-Type", evidencia.TipoMime)
+	w.Header().Set("Content-Length", strconv.FormatInt(evidencia.Tamano, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", evidencia.NombreArchivo))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if _, err := io.Copy(w, contenido); err != nil {
+		log.Printf("Error al enviar evidencia %d: %v", id, err)
+	}
+}
+
+// DeleteEvidencia maneja DELETE /api/evidencias/{id}
+func (h *Handler) DeleteEvidencia(w http.ResponseWriter, r *http.Request, id int) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !puedeModificar(r) {
+		sendError(w, "No tiene permiso para eliminar evidencias", http.StatusForbidden)
+		return
+	}
+
+	if err := h.verificarMiembroEvidencia(r, id, domain.RolBodegaEditor); err != nil {
+		sendServiceError(w, err, "Error al verificar permisos", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.service.Eliminar(id); err != nil {
+		log.Printf("Error al eliminar evidencia: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, map[string]int{"idEvidencia": id})
+}
+
+// verificarMiembro comprueba que un usuario con rol bodega sea miembro, con al menos el
+// rol mínimo, de la bodega dueña de la evaluación, y que un auditor la tenga asignada
+func (h *Handler) verificarMiembro(r *http.Request, idEvaluacion int, minimo string) error {
+	claims, _ := r.Context().Value("claims").(*auth.Claims)
+	if claims != nil && claims.Rol == "auditor" {
+		return h.service.VerificarAuditor(idEvaluacion, claims.IdUsuario)
+	}
+	if claims == nil || claims.Rol != "bodega" {
+		return nil
+	}
+
+	idBodega, err := h.service.IdBodega(idEvaluacion)
+	if err != nil {
+		return err
+	}
+
+	return h.miembros.Verificar(claims, idBodega, minimo)
+}
+
+// verificarMiembroEvidencia es verificarMiembro para la evaluación de una evidencia
+func (h *Handler) verificarMiembroEvidencia(r *http.Request, idEvidencia int, minimo string) error {
+	claims, _ := r.Context().Value("claims").(*auth.Claims)
+	if claims == nil || (claims.Rol != "bodega" && claims.Rol != "auditor") {
+		return nil
+	}
+
+	evidencia, err := h.service.GetByID(idEvidencia)
+	if err != nil {
+		return err
+	}
+
+	return h.verificarMiembro(r, evidencia.IdEvaluacion, minimo)
+}
+
+// puedeAcceder indica si el usuario puede ver y descargar evidencias: los miembros de la
+// bodega dueña de la evaluación (ver verificarMiembro), los auditores y los administradores
+func puedeAcceder(r *http.Request) bool {
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	if !ok || claims == nil {
+		return false
+	}
+
+	switch claims.Rol {
+	case "admin", "auditor", "bodega":
+		return true
+	default:
+		return false
+	}
+}
+
+// puedeModificar indica si el usuario puede subir o eliminar evidencias (los
+// auditores solo pueden consultarlas)
+func puedeModificar(r *http.Request) bool {
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	return ok && claims != nil && (claims.Rol == "admin" || claims.Rol == "bodega")
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// sendServiceError traduce los errores de negocio del servicio al código HTTP adecuado
+func sendServiceError(w http.ResponseWriter, err error, fallback string, fallbackStatus int) {
+	switch {
+	case errors.Is(err, ErrEvidenciaNoEncontrada), errors.Is(err, evaluacion.ErrEvaluacionNoEncontrada),
+		errors.Is(err, evaluacion.ErrRespuestaNoEncontrada):
+		sendError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrArchivoMuyGrande):
+		sendError(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, ErrTipoNoPermitido):
+		sendError(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, evaluacion.ErrEvaluacionNoEditable):
+		sendError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, membresia.ErrSinAcceso), errors.Is(err, evaluacion.ErrAuditorNoAsignado):
+		sendError(w, err.Error(), http.StatusForbidden)
+	default:
+		sendError(w, fallback, fallbackStatus)
+	}
+}
@@ -0,0 +1,134 @@
+// RUTA: coviar-backend/internal/evidencia/repository.go
+package evidencia
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// ordenAscendente ordena los resultados de menor a mayor
+var ordenAscendente = postgrest.OrderOpts{Ascending: true}
+
+// fila agrega la ruta de almacenamiento, que la entidad de dominio no expone en JSON
+type fila struct {
+	domain.Evidencia
+	Ruta string `json:"ruta"`
+}
+
+func (f fila) evidencia() domain.Evidencia {
+	evidencia := f.Evidencia
+	evidencia.Ruta = f.Ruta
+	return evidencia
+}
+
+// Repository maneja el acceso a datos de Evidencia
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// Create registra una evidencia ya guardada en el almacenamiento
+func (r *Repository) Create(evidencia *domain.Evidencia) error {
+	evidenciaMap := map[string]interface{}{
+		"idRespuesta":    evidencia.IdRespuesta,
+		"idEvaluacion":   evidencia.IdEvaluacion,
+		"idIndicador":    evidencia.IdIndicador,
+		"nombre_archivo": evidencia.NombreArchivo,
+		"tipo_mime":      evidencia.TipoMime,
+		"tamano":         evidencia.Tamano,
+		"ruta":           evidencia.Ruta,
+		"idUsuario":      evidencia.IdUsuario,
+		"created_at":     time.Now().Format(time.RFC3339),
+	}
+
+	data, _, err := r.db.From("evidencia").
+		Insert(evidenciaMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []fila
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*evidencia = result[0].evidencia()
+	}
+
+	return nil
+}
+
+// FindByID obtiene una evidencia por ID
+func (r *Repository) FindByID(id int) (*domain.Evidencia, error) {
+	data, _, err := r.db.From("evidencia").
+		Select("*", "", false).
+		Eq("idEvidencia", fmt.Sprintf("%d", id)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var filas []fila
+	if err := json.Unmarshal(data, &filas); err != nil {
+		return nil, err
+	}
+
+	if len(filas) == 0 {
+		return nil, ErrEvidenciaNoEncontrada
+	}
+
+	evidencia := filas[0].evidencia()
+	return &evidencia, nil
+}
+
+// FindByEvaluacion obtiene las evidencias de una evaluación. Si idIndicador es mayor
+// que cero, solo las de la respuesta a ese indicador.
+func (r *Repository) FindByEvaluacion(idEvaluacion int, idIndicador int) ([]domain.Evidencia, error) {
+	query := r.db.From("evidencia").
+		Select("*", "", false).
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion))
+
+	if idIndicador > 0 {
+		query = query.Eq("idIndicador", fmt.Sprintf("%d", idIndicador))
+	}
+
+	data, _, err := query.Order("idEvidencia", &ordenAscendente).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var filas []fila
+	if err := json.Unmarshal(data, &filas); err != nil {
+		return nil, err
+	}
+
+	evidencias := make([]domain.Evidencia, len(filas))
+	for i, f := range filas {
+		evidencias[i] = f.evidencia()
+	}
+
+	return evidencias, nil
+}
+
+// Delete elimina el registro de una evidencia
+func (r *Repository) Delete(id int) error {
+	_, _, err := r.db.From("evidencia").
+		Delete("minimal", "").
+		Eq("idEvidencia", fmt.Sprintf("%d", id)).
+		Execute()
+
+	return err
+}
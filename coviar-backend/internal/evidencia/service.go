@@ -0,0 +1,229 @@
+// RUTA: coviar-backend/internal/evidencia/service.go
+package evidencia
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+	"github.com/carli/coviar-backend/internal/platform/storage"
+)
+
+// TamanoMaximo es el tamaño máximo permitido para un archivo de evidencia (10 MB)
+const TamanoMaximo = 10 << 20
+
+// tiposPermitidos relaciona los tipos MIME aceptados con la extensión con que se guardan
+var tiposPermitidos = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+}
+
+// Errores de negocio que el handler traduce a códigos HTTP
+var (
+	ErrEvidenciaNoEncontrada = errors.New("evidencia no encontrada")
+	ErrTipoNoPermitido       = errors.New("tipo de archivo no permitido: solo se aceptan PDF, JPG, PNG o WEBP")
+	ErrArchivoMuyGrande      = fmt.Errorf("el archivo supera el tamaño máximo de %d MB", TamanoMaximo>>20)
+	ErrArchivoVacio          = errors.New("el archivo está vacío")
+)
+
+// Service contiene la lógica de negocio de Evidencia
+type Service struct {
+	repo         *Repository
+	storage      storage.Storage
+	evaluaciones *evaluacion.Service
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, store storage.Storage, evaluacionService *evaluacion.Service) *Service {
+	return &Service{repo: repo, storage: store, evaluaciones: evaluacionService}
+}
+
+// Subir valida y guarda un archivo como evidencia de la respuesta a un indicador.
+// El tipo de archivo se determina por su contenido, no por la extensión ni por lo
+// que declare el cliente.
+func (s *Service) Subir(idEvaluacion int, idIndicador int, idUsuario int, nombre string, tamano int64, contenido io.Reader) (*domain.Evidencia, error) {
+	if tamano <= 0 {
+		return nil, ErrArchivoVacio
+	}
+	if tamano > TamanoMaximo {
+		return nil, ErrArchivoMuyGrande
+	}
+
+	ev, err := s.evaluaciones.GetByID(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.EvaluacionEditable(ev.Estado) {
+		return nil, evaluacion.ErrEvaluacionNoEditable
+	}
+
+	respuesta, err := s.evaluaciones.GetRespuesta(idEvaluacion, idIndicador)
+	if err != nil {
+		return nil, err
+	}
+
+	lector := bufio.NewReaderSize(contenido, 512)
+	cabecera, err := lector.Peek(512)
+	if err != nil && err != io.EOF && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+
+	tipo := http.DetectContentType(cabecera)
+	if i := strings.Index(tipo, ";"); i >= 0 {
+		tipo = tipo[:i]
+	}
+	extension, ok := tiposPermitidos[tipo]
+	if !ok {
+		return nil, ErrTipoNoPermitido
+	}
+
+	nombreAlmacenado, err := nombreAleatorio()
+	if err != nil {
+		return nil, err
+	}
+	ruta := fmt.Sprintf("evaluaciones/%d/%s%s", idEvaluacion, nombreAlmacenado, extension)
+
+	if err := s.storage.Guardar(ruta, io.LimitReader(lector, TamanoMaximo)); err != nil {
+		return nil, fmt.Errorf("error al guardar el archivo: %w", err)
+	}
+
+	evidencia := &domain.Evidencia{
+		IdRespuesta:   respuesta.IdRespuesta,
+		IdEvaluacion:  idEvaluacion,
+		IdIndicador:   idIndicador,
+		NombreArchivo: limpiarNombre(nombre, extension),
+		TipoMime:      tipo,
+		Tamano:        tamano,
+		Ruta:          ruta,
+		IdUsuario:     idUsuario,
+	}
+
+	if err := s.repo.Create(evidencia); err != nil {
+		// No dejar archivos huérfanos en el almacenamiento
+		if errEliminar := s.storage.Eliminar(ruta); errEliminar != nil {
+			log.Printf("Error al eliminar archivo huérfano %s: %v", ruta, errEliminar)
+		}
+		return nil, err
+	}
+
+	return evidencia, nil
+}
+
+// GetByEvaluacion obtiene las evidencias de una evaluación, opcionalmente de un solo indicador
+func (s *Service) GetByEvaluacion(idEvaluacion int, idIndicador int) ([]domain.Evidencia, error) {
+	if _, err := s.evaluaciones.GetByID(idEvaluacion); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByEvaluacion(idEvaluacion, idIndicador)
+}
+
+// IdBodega devuelve la bodega dueña de una evaluación, para verificar la membresía
+func (s *Service) IdBodega(idEvaluacion int) (int, error) {
+	ev, err := s.evaluaciones.GetByID(idEvaluacion)
+	if err != nil {
+		return 0, err
+	}
+
+	return ev.IdBodega, nil
+}
+
+// VerificarAuditor comprueba que la evaluación esté asignada al auditor
+func (s *Service) VerificarAuditor(idEvaluacion int, idAuditor int) error {
+	return s.evaluaciones.VerificarAuditor(idEvaluacion, evaluacion.Actor{IdUsuario: idAuditor, Rol: "auditor"})
+}
+
+// GetByID obtiene una evidencia por ID
+func (s *Service) GetByID(id int) (*domain.Evidencia, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+
+	return s.repo.FindByID(id)
+}
+
+// Abrir obtiene una evidencia junto con su contenido. Quien llama debe cerrar el lector.
+func (s *Service) Abrir(id int) (*domain.Evidencia, io.ReadCloser, error) {
+	evidencia, err := s.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contenido, err := s.storage.Abrir(evidencia.Ruta)
+	if errors.Is(err, storage.ErrArchivoNoEncontrado) {
+		return nil, nil, ErrEvidenciaNoEncontrada
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return evidencia, contenido, nil
+}
+
+// Eliminar borra una evidencia mientras la evaluación siga siendo editable
+func (s *Service) Eliminar(id int) error {
+	evidencia, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	ev, err := s.evaluaciones.GetByID(evidencia.IdEvaluacion)
+	if err != nil {
+		return err
+	}
+	if !domain.EvaluacionEditable(ev.Estado) {
+		return evaluacion.ErrEvaluacionNoEditable
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+
+	if err := s.storage.Eliminar(evidencia.Ruta); err != nil && !errors.Is(err, storage.ErrArchivoNoEncontrado) {
+		log.Printf("Error al eliminar archivo %s: %v", evidencia.Ruta, err)
+	}
+
+	return nil
+}
+
+func nombreAleatorio() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// limpiarNombre conserva solo el nombre base del archivo subido y asegura la
+// extensión que corresponde a su contenido
+func limpiarNombre(nombre string, extension string) string {
+	nombre = filepath.Base(strings.ReplaceAll(nombre, "\\", "/"))
+	nombre = strings.Map(func(r rune) rune {
+		if r < 32 || r == '"' {
+			return -1
+		}
+		return r
+	}, nombre)
+
+	if nombre == "" || nombre == "." || nombre == "/" {
+		nombre = "evidencia"
+	}
+
+	actual := strings.ToLower(filepath.Ext(nombre))
+	if actual != extension && !(extension == ".jpg" && actual == ".jpeg") {
+		nombre += extension
+	}
+
+	return nombre
+}
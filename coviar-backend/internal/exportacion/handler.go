@@ -0,0 +1,219 @@
+// RUTA: coviar-backend/internal/exportacion/handler.go
+package exportacion
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/platform/xlsx"
+)
+
+// Handler maneja las peticiones HTTP de exportación
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ExportEvaluaciones maneja GET /api/admin/exportaciones/evaluaciones
+// Parámetros: formato (csv|xlsx), modo (evaluacion|respuesta), desde y hasta (AAAA-MM-DD),
+// provincia, departamento, idSegmento y estado.
+func (h *Handler) ExportEvaluaciones(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+
+	formato := query.Get("formato")
+	if formato == "" {
+		formato = "csv"
+	}
+	if formato != "csv" && formato != "xlsx" {
+		sendError(w, "Formato inválido: debe ser csv o xlsx", http.StatusBadRequest)
+		return
+	}
+
+	modo := query.Get("modo")
+	if modo == "" {
+		modo = ModoEvaluacion
+	}
+	if modo != ModoEvaluacion && modo != ModoRespuesta {
+		sendError(w, "Modo inválido: debe ser evaluacion o respuesta", http.StatusBadRequest)
+		return
+	}
+
+	filtro, err := parseFiltro(query)
+	if err != nil {
+		sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	nombre := fmt.Sprintf("evaluaciones-%s-%s.%s", modo, time.Now().Format("20060102"), formato)
+	destino := &respuestaEnCurso{ResponseWriter: w}
+
+	var escritor Escritor
+	var cerrar func() error
+	if formato == "xlsx" {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		planilla, err := xlsx.NewWriter(destino, "Evaluaciones")
+		if err != nil {
+			log.Printf("Error al crear la planilla: %v", err)
+			sendError(w, "Error al exportar evaluaciones", http.StatusInternalServerError)
+			return
+		}
+		escritor, cerrar = planilla, planilla.Close
+	} else {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		escritor, cerrar = newCSVEscritor(destino), func() error { return nil }
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", nombre))
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := h.service.Exportar(&conVaciado{Escritor: escritor, destino: w}, filtro, modo); err != nil {
+		log.Printf("Error al exportar evaluaciones: %v", err)
+		if !destino.escrito {
+			sendError(w, "Error al exportar evaluaciones", http.StatusInternalServerError)
+		}
+		// Si ya se enviaron datos no se puede cambiar el código de estado: el archivo queda truncado
+		return
+	}
+
+	if err := cerrar(); err != nil {
+		log.Printf("Error al cerrar la exportación: %v", err)
+	}
+}
+
+// parseFiltro arma el filtro a partir de los parámetros de la URL
+func parseFiltro(query url.Values) (Filtro, error) {
+	filtro := Filtro{
+		Provincia:    query.Get("provincia"),
+		Departamento: query.Get("departamento"),
+		Estado:       query.Get("estado"),
+	}
+
+	for _, campo := range []struct {
+		nombre  string
+		destino **time.Time
+	}{{"desde", &filtro.Desde}, {"hasta", &filtro.Hasta}} {
+		valor := query.Get(campo.nombre)
+		if valor == "" {
+			continue
+		}
+		fecha, err := time.Parse("2006-01-02", valor)
+		if err != nil {
+			return filtro, fmt.Errorf("el parámetro %s debe tener el formato AAAA-MM-DD", campo.nombre)
+		}
+		*campo.destino = &fecha
+	}
+
+	if filtro.Desde != nil && filtro.Hasta != nil && filtro.Hasta.Before(*filtro.Desde) {
+		return filtro, fmt.Errorf("la fecha hasta no puede ser anterior a la fecha desde")
+	}
+
+	if idSegmento := query.Get("idSegmento"); idSegmento != "" {
+		id, err := strconv.Atoi(idSegmento)
+		if err != nil || id <= 0 {
+			return filtro, fmt.Errorf("ID de segmento inválido")
+		}
+		filtro.IdSegmento = id
+	}
+
+	return filtro, nil
+}
+
+// csvEscritor adapta csv.Writer a la interfaz Escritor
+type csvEscritor struct {
+	buf *bufio.Writer
+	w   *csv.Writer
+}
+
+func newCSVEscritor(destino io.Writer) *csvEscritor {
+	buf := bufio.NewWriter(destino)
+	// BOM para que Excel reconozca los acentos al abrir el CSV
+	buf.WriteString("\xEF\xBB\xBF")
+	return &csvEscritor{buf: buf, w: csv.NewWriter(buf)}
+}
+
+func (c *csvEscritor) WriteHeader(titulos []string) error {
+	return c.w.Write(titulos)
+}
+
+func (c *csvEscritor) WriteRow(valores []interface{}) error {
+	fila := make([]string, len(valores))
+	for i, valor := range valores {
+		switch v := valor.(type) {
+		case nil:
+			fila[i] = ""
+		case float64:
+			fila[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			fila[i] = fmt.Sprint(v)
+		}
+	}
+	return c.w.Write(fila)
+}
+
+func (c *csvEscritor) Flush() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return err
+	}
+	return c.buf.Flush()
+}
+
+// conVaciado envía al cliente lo escrito después de cada tanda
+type conVaciado struct {
+	Escritor
+	destino http.ResponseWriter
+}
+
+func (c *conVaciado) Flush() error {
+	if err := c.Escritor.Flush(); err != nil {
+		return err
+	}
+	if flusher, ok := c.destino.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// respuestaEnCurso registra si ya se empezó a enviar el cuerpo de la respuesta
+type respuestaEnCurso struct {
+	http.ResponseWriter
+	escrito bool
+}
+
+func (r *respuestaEnCurso) Write(p []byte) (int, error) {
+	r.escrito = true
+	return r.ResponseWriter.Write(p)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Del("Content-Disposition")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
@@ -0,0 +1,137 @@
+// RUTA: coviar-backend/internal/exportacion/repository.go
+package exportacion
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/platform/database"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// filaEvaluacion es una evaluación junto con los datos de su bodega
+type filaEvaluacion struct {
+	domain.Evaluacion
+	Bodega *struct {
+		Nombre       string  `json:"nombre"`
+		Cuit         int64   `json:"cuit"`
+		Provincia    *string `json:"provincia"`
+		Departamento *string `json:"departamento"`
+	} `json:"bodega"`
+}
+
+// indicadorResumido son los datos de un indicador que se muestran en la exportación
+type indicadorResumido struct {
+	IdIndicador int    `json:"idIndicador"`
+	Codigo      string `json:"codigo"`
+	Nombre      string `json:"nombre"`
+}
+
+// Repository lee los datos a exportar
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindEvaluaciones obtiene hasta limite evaluaciones que cumplen el filtro, con ID mayor a
+// desdeId, ordenadas por ID. Se pagina por ID para no depender de desplazamientos.
+func (r *Repository) FindEvaluaciones(filtro Filtro, desdeId int, limite int) ([]filaEvaluacion, error) {
+	columnas := "*,bodega(nombre,cuit,provincia,departamento)"
+	if filtro.Provincia != "" || filtro.Departamento != "" {
+		// !inner descarta las evaluaciones cuya bodega no cumple el filtro
+		columnas = "*,bodega!inner(nombre,cuit,provincia,departamento)"
+	}
+
+	query := r.db.From("evaluacion").
+		Select(columnas, "", false).
+		Gt("idEvaluacion", strconv.Itoa(desdeId))
+
+	if filtro.Provincia != "" {
+		query = query.Eq("bodega.provincia", filtro.Provincia)
+	}
+	if filtro.Departamento != "" {
+		query = query.Eq("bodega.departamento", filtro.Departamento)
+	}
+	if filtro.IdSegmento > 0 {
+		query = query.Eq("idSegmento", strconv.Itoa(filtro.IdSegmento))
+	}
+	if filtro.Estado != "" {
+		query = query.Eq("estado", filtro.Estado)
+	}
+
+	// Dos filtros sobre la misma columna se pisan entre sí, por eso el rango va en un and
+	var rango []string
+	if filtro.Desde != nil {
+		rango = append(rango, "fecha_inicio.gte."+filtro.Desde.Format("2006-01-02"))
+	}
+	if filtro.Hasta != nil {
+		// Hasta es inclusivo: se compara contra el día siguiente
+		rango = append(rango, "fecha_inicio.lt."+filtro.Hasta.AddDate(0, 0, 1).Format("2006-01-02"))
+	}
+	if len(rango) > 0 {
+		query = query.And(strings.Join(rango, ","), "")
+	}
+
+	data, _, err := query.
+		Order("idEvaluacion", &database.OrdenAscendente).
+		Limit(limite, "").
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluaciones []filaEvaluacion
+	if err := json.Unmarshal(data, &evaluaciones); err != nil {
+		return nil, err
+	}
+
+	return evaluaciones, nil
+}
+
+// FindRespuestas obtiene todas las respuestas de las evaluaciones dadas
+func (r *Repository) FindRespuestas(idsEvaluacion []int) ([]domain.Respuesta, error) {
+	if len(idsEvaluacion) == 0 {
+		return nil, nil
+	}
+
+	return database.LeerPorTandas(func() *postgrest.FilterBuilder {
+		return r.db.From("respuesta").
+			Select("*", "", false).
+			In("idEvaluacion", database.ToStrings(idsEvaluacion))
+	}, "idRespuesta", func(respuesta *domain.Respuesta) int { return respuesta.IdRespuesta })
+}
+
+// FindIndicadores obtiene el código y el nombre de todos los indicadores, de todas las
+// versiones del catálogo
+func (r *Repository) FindIndicadores() ([]indicadorResumido, error) {
+	return database.LeerPorTandas(func() *postgrest.FilterBuilder {
+		return r.db.From("indicador").
+			Select(`"idIndicador",codigo,nombre`, "", false)
+	}, "idIndicador", func(indicador *indicadorResumido) int { return indicador.IdIndicador })
+}
+
+// FindNivelesSostenibilidad obtiene los niveles de sostenibilidad de todos los segmentos
+func (r *Repository) FindNivelesSostenibilidad() ([]domain.NivelSostenibilidad, error) {
+	data, _, err := r.db.From("nivel_sostenibilidad").
+		Select("*", "", false).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var niveles []domain.NivelSostenibilidad
+	if err := json.Unmarshal(data, &niveles); err != nil {
+		return nil, err
+	}
+
+	return niveles, nil
+}
@@ -0,0 +1,263 @@
+// RUTA: coviar-backend/internal/exportacion/service.go
+package exportacion
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/puntaje"
+	"github.com/carli/coviar-backend/internal/segmento"
+)
+
+// Modos de exportación
+const (
+	ModoEvaluacion = "evaluacion" // una fila por evaluación, con una columna por indicador
+	ModoRespuesta  = "respuesta"  // una fila por respuesta (formato largo)
+)
+
+// tamanoTanda es la cantidad de evaluaciones que se leen y escriben por vez
+const tamanoTanda = 200
+
+// Filtro limita las evaluaciones a exportar. Los campos vacíos no filtran.
+type Filtro struct {
+	Desde        *time.Time // fecha de inicio desde (inclusive)
+	Hasta        *time.Time // fecha de inicio hasta (inclusive)
+	Provincia    string
+	Departamento string
+	IdSegmento   int
+	Estado       string
+}
+
+// Escritor recibe las filas de la exportación (CSV, XLSX, ...)
+type Escritor interface {
+	WriteHeader(titulos []string) error
+	WriteRow(valores []interface{}) error
+	Flush() error
+}
+
+// Service exporta evaluaciones para su análisis
+type Service struct {
+	repo      *Repository
+	segmentos *segmento.Service
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, segmentoService *segmento.Service) *Service {
+	return &Service{repo: repo, segmentos: segmentoService}
+}
+
+// catalogos son los nombres que se resuelven una sola vez antes de recorrer las evaluaciones
+type catalogos struct {
+	segmentos   map[int]string
+	niveles     map[int]string
+	indicadores map[int]indicadorResumido
+	codigos     []string // todos los códigos de indicador, ordenados
+}
+
+// Exportar escribe las evaluaciones que cumplen el filtro de a tandas, sin cargarlas
+// todas en memoria. Después de cada tanda se vacía el escritor.
+func (s *Service) Exportar(escritor Escritor, filtro Filtro, modo string) error {
+	if modo != ModoEvaluacion && modo != ModoRespuesta {
+		return fmt.Errorf("modo inválido: debe ser %s o %s", ModoEvaluacion, ModoRespuesta)
+	}
+
+	cat, err := s.cargarCatalogos()
+	if err != nil {
+		return err
+	}
+
+	if modo == ModoEvaluacion {
+		err = escritor.WriteHeader(append(columnasEvaluacion(), cat.codigos...))
+	} else {
+		err = escritor.WriteHeader(columnasRespuesta())
+	}
+	if err != nil {
+		return err
+	}
+
+	desdeId := 0
+	for {
+		evaluaciones, err := s.repo.FindEvaluaciones(filtro, desdeId, tamanoTanda)
+		if err != nil {
+			return err
+		}
+		if len(evaluaciones) == 0 {
+			return escritor.Flush()
+		}
+
+		ids := make([]int, len(evaluaciones))
+		for i, ev := range evaluaciones {
+			ids[i] = ev.IdEvaluacion
+		}
+
+		respuestas, err := s.repo.FindRespuestas(ids)
+		if err != nil {
+			return err
+		}
+		porEvaluacion := make(map[int][]domain.Respuesta)
+		for _, respuesta := range respuestas {
+			porEvaluacion[respuesta.IdEvaluacion] = append(porEvaluacion[respuesta.IdEvaluacion], respuesta)
+		}
+
+		for _, ev := range evaluaciones {
+			if modo == ModoEvaluacion {
+				err = escritor.WriteRow(filaAncha(ev, porEvaluacion[ev.IdEvaluacion], cat))
+			} else {
+				err = escribirFilasLargas(escritor, ev, porEvaluacion[ev.IdEvaluacion], cat)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := escritor.Flush(); err != nil {
+			return err
+		}
+
+		if len(evaluaciones) < tamanoTanda {
+			return nil
+		}
+		desdeId = evaluaciones[len(evaluaciones)-1].IdEvaluacion
+	}
+}
+
+func (s *Service) cargarCatalogos() (*catalogos, error) {
+	cat := &catalogos{
+		segmentos:   make(map[int]string),
+		niveles:     make(map[int]string),
+		indicadores: make(map[int]indicadorResumido),
+	}
+
+	segmentos, err := s.segmentos.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, seg := range segmentos {
+		cat.segmentos[seg.IdSegmento] = seg.Nombre
+	}
+
+	niveles, err := s.repo.FindNivelesSostenibilidad()
+	if err != nil {
+		return nil, err
+	}
+	for _, nivel := range niveles {
+		cat.niveles[nivel.IdNvSos] = nivel.Nombre
+	}
+
+	indicadores, err := s.repo.FindIndicadores()
+	if err != nil {
+		return nil, err
+	}
+	vistos := make(map[string]bool)
+	for _, indicador := range indicadores {
+		cat.indicadores[indicador.IdIndicador] = indicador
+		if !vistos[indicador.Codigo] {
+			vistos[indicador.Codigo] = true
+			cat.codigos = append(cat.codigos, indicador.Codigo)
+		}
+	}
+	sort.Slice(cat.codigos, func(a, b int) bool {
+		return domain.CompararCodigos(cat.codigos[a], cat.codigos[b]) < 0
+	})
+
+	return cat, nil
+}
+
+func columnasEvaluacion() []string {
+	return []string{
+		"ID evaluación", "Bodega", "CUIT", "Provincia", "Departamento", "Segmento", "Versión del catálogo",
+		"Estado", "Fecha de inicio", "Fecha de finalización", "Puntaje", "Puntaje máximo",
+		"Porcentaje", "Nivel de sostenibilidad",
+	}
+}
+
+func columnasRespuesta() []string {
+	return []string{
+		"ID evaluación", "Bodega", "CUIT", "Provincia", "Departamento", "Segmento", "Estado", "Fecha de inicio",
+		"Capítulo", "Código", "Indicador", "Nivel",
+	}
+}
+
+// datosComunes son las columnas de la evaluación y su bodega que comparten ambos modos
+func datosComunes(ev filaEvaluacion, cat *catalogos) (bodega interface{}, cuit interface{}, provincia interface{}, departamento interface{}, seg interface{}) {
+	if ev.Bodega != nil {
+		bodega = ev.Bodega.Nombre
+		cuit = ev.Bodega.Cuit
+		if ev.Bodega.Provincia != nil {
+			provincia = *ev.Bodega.Provincia
+		}
+		if ev.Bodega.Departamento != nil {
+			departamento = *ev.Bodega.Departamento
+		}
+	}
+	if nombre, ok := cat.segmentos[ev.IdSegmento]; ok {
+		seg = nombre
+	}
+	return bodega, cuit, provincia, departamento, seg
+}
+
+func filaAncha(ev filaEvaluacion, respuestas []domain.Respuesta, cat *catalogos) []interface{} {
+	bodega, cuit, provincia, departamento, seg := datosComunes(ev, cat)
+
+	var version, fechaCompletado, total, maximo, porcentaje, nivel interface{}
+	if ev.IdVersion != 0 {
+		version = ev.IdVersion
+	}
+	if ev.FechaCompletado != nil {
+		fechaCompletado = *ev.FechaCompletado
+	}
+	if ev.PuntajeTotal != nil {
+		total = *ev.PuntajeTotal
+	}
+	if ev.PuntajeMaximo != nil {
+		maximo = *ev.PuntajeMaximo
+		if ev.PuntajeTotal != nil {
+			porcentaje = puntaje.Porcentaje(*ev.PuntajeTotal, *ev.PuntajeMaximo)
+		}
+	}
+	if ev.IdNvSos != nil {
+		nivel = cat.niveles[*ev.IdNvSos]
+	}
+
+	fila := []interface{}{
+		ev.IdEvaluacion, bodega, cuit, provincia, departamento, seg, version,
+		ev.Estado, ev.FechaInicio, fechaCompletado, total, maximo, porcentaje, nivel,
+	}
+
+	niveles := make(map[string]int, len(respuestas))
+	for _, respuesta := range respuestas {
+		niveles[cat.indicadores[respuesta.IdIndicador].Codigo] = respuesta.Nivel
+	}
+	for _, codigo := range cat.codigos {
+		if valor, ok := niveles[codigo]; ok {
+			fila = append(fila, valor)
+		} else {
+			fila = append(fila, nil)
+		}
+	}
+
+	return fila
+}
+
+func escribirFilasLargas(escritor Escritor, ev filaEvaluacion, respuestas []domain.Respuesta, cat *catalogos) error {
+	bodega, cuit, provincia, departamento, seg := datosComunes(ev, cat)
+
+	sort.Slice(respuestas, func(a, b int) bool {
+		return domain.CompararCodigos(cat.indicadores[respuestas[a].IdIndicador].Codigo, cat.indicadores[respuestas[b].IdIndicador].Codigo) < 0
+	})
+
+	for _, respuesta := range respuestas {
+		indicador := cat.indicadores[respuesta.IdIndicador]
+		fila := []interface{}{
+			ev.IdEvaluacion, bodega, cuit, provincia, departamento, seg, ev.Estado, ev.FechaInicio,
+			domain.NumeroCapitulo(indicador.Codigo), indicador.Codigo, indicador.Nombre, respuesta.Nivel,
+		}
+		if err := escritor.WriteRow(fila); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
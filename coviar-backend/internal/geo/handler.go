@@ -0,0 +1,101 @@
+// RUTA: coviar-backend/internal/geo/handler.go
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxAge es el tiempo, en segundos, que se puede reutilizar una respuesta: el catálogo
+// solo cambia con una nueva versión del servidor
+const maxAge = 24 * 60 * 60
+
+// Handler maneja las consultas del catálogo geográfico
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// Route despacha las consultas de /api/geo/. No requieren sesión.
+//
+//	GET /api/geo/provincias
+//	GET /api/geo/departamentos?provincia=
+//	GET /api/geo/distritos?provincia=&departamento=
+func (h *Handler) Route(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	var datos interface{}
+	var err error
+
+	switch strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/geo/"), "/") {
+	case "provincias":
+		datos = h.service.Provincias()
+	case "departamentos":
+		if query.Get("provincia") == "" {
+			sendError(w, "Falta la provincia", http.StatusBadRequest)
+			return
+		}
+		datos, err = h.service.Departamentos(query.Get("provincia"))
+	case "distritos":
+		if query.Get("provincia") == "" || query.Get("departamento") == "" {
+			sendError(w, "Faltan la provincia o el departamento", http.StatusBadRequest)
+			return
+		}
+		datos, err = h.service.Distritos(query.Get("provincia"), query.Get("departamento"))
+	default:
+		sendError(w, "Ruta no encontrada", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrProvinciaNoEncontrada) || errors.Is(err, ErrDepartamentoNoEncontrado) {
+			sendError(w, err.Error(), http.StatusNotFound)
+		} else {
+			sendError(w, "Error al consultar el catálogo geográfico", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+	sendSuccess(w, datos)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
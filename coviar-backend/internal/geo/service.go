@@ -0,0 +1,195 @@
+// RUTA: coviar-backend/internal/geo/service.go
+package geo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// datosArgentina es el catálogo de provincias, departamentos y distritos. Los distritos
+// solo están cargados para algunos departamentos; en el resto se aceptan como texto libre.
+//
+//go:embed datos/argentina.json
+var datosArgentina []byte
+
+// Errores de validación de la ubicación
+var (
+	ErrProvinciaNoEncontrada    = errors.New("provincia no encontrada")
+	ErrDepartamentoNoEncontrado = errors.New("el departamento no pertenece a la provincia")
+	ErrDistritoNoEncontrado     = errors.New("el distrito no pertenece al departamento")
+	ErrDistritoSinDepartamento  = errors.New("para indicar el distrito hay que indicar el departamento")
+)
+
+// aliasProvincias son otras formas habituales de escribir el nombre de una provincia
+var aliasProvincias = map[string]string{
+	"caba":                   "Ciudad Autónoma de Buenos Aires",
+	"capital federal":        "Ciudad Autónoma de Buenos Aires",
+	"ciudad de buenos aires": "Ciudad Autónoma de Buenos Aires",
+	"tierra del fuego, antartida e islas del atlantico sur": "Tierra del Fuego",
+}
+
+// sinAcentos quita tildes y diéresis para comparar nombres escritos de distinta forma
+var sinAcentos = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u")
+
+// Departamento de una provincia, con sus distritos si el catálogo los detalla
+type Departamento struct {
+	Nombre    string   `json:"nombre"`
+	Distritos []string `json:"distritos"`
+}
+
+// Provincia de la República Argentina
+type Provincia struct {
+	Nombre        string         `json:"nombre"`
+	Departamentos []Departamento `json:"departamentos"`
+}
+
+// Ubicacion es una ubicación validada, con los nombres tal como figuran en el catálogo
+type Ubicacion struct {
+	Provincia    string  `json:"provincia"`
+	Departamento *string `json:"departamento"`
+	Distrito     *string `json:"distrito"`
+}
+
+// Service responde consultas sobre el catálogo geográfico y valida ubicaciones
+type Service struct {
+	provincias []Provincia
+	indice     map[string]*Provincia
+}
+
+// NewService carga el catálogo geográfico incluido en el binario
+func NewService() (*Service, error) {
+	var datos struct {
+		Provincias []Provincia `json:"provincias"`
+	}
+	if err := json.Unmarshal(datosArgentina, &datos); err != nil {
+		return nil, fmt.Errorf("catálogo geográfico inválido: %w", err)
+	}
+
+	s := &Service{provincias: datos.Provincias, indice: make(map[string]*Provincia)}
+	for i := range s.provincias {
+		s.indice[clave(s.provincias[i].Nombre)] = &s.provincias[i]
+	}
+
+	return s, nil
+}
+
+// Provincias devuelve los nombres de todas las provincias
+func (s *Service) Provincias() []string {
+	nombres := make([]string, len(s.provincias))
+	for i, provincia := range s.provincias {
+		nombres[i] = provincia.Nombre
+	}
+	return nombres
+}
+
+// Departamentos devuelve los departamentos de una provincia
+func (s *Service) Departamentos(provincia string) ([]string, error) {
+	prov, err := s.provincia(provincia)
+	if err != nil {
+		return nil, err
+	}
+
+	nombres := make([]string, len(prov.Departamentos))
+	for i, departamento := range prov.Departamentos {
+		nombres[i] = departamento.Nombre
+	}
+	return nombres, nil
+}
+
+// Distritos devuelve los distritos de un departamento. La lista vacía indica que el
+// catálogo no los detalla.
+func (s *Service) Distritos(provincia string, departamento string) ([]string, error) {
+	prov, err := s.provincia(provincia)
+	if err != nil {
+		return nil, err
+	}
+
+	dep, err := buscarDepartamento(prov, departamento)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{}, dep.Distritos...), nil
+}
+
+// NormalizarProvincia devuelve el nombre de la provincia tal como figura en el catálogo
+func (s *Service) NormalizarProvincia(provincia string) (string, error) {
+	prov, err := s.provincia(provincia)
+	if err != nil {
+		return "", err
+	}
+	return prov.Nombre, nil
+}
+
+// Validar comprueba que el departamento pertenezca a la provincia y el distrito al
+// departamento, sin distinguir mayúsculas ni tildes. El departamento y el distrito son
+// opcionales (texto vacío).
+func (s *Service) Validar(provincia string, departamento string, distrito string) (*Ubicacion, error) {
+	prov, err := s.provincia(provincia)
+	if err != nil {
+		return nil, err
+	}
+	ubicacion := &Ubicacion{Provincia: prov.Nombre}
+
+	departamento = strings.TrimSpace(departamento)
+	distrito = strings.TrimSpace(distrito)
+	if departamento == "" {
+		if distrito != "" {
+			return nil, ErrDistritoSinDepartamento
+		}
+		return ubicacion, nil
+	}
+
+	dep, err := buscarDepartamento(prov, departamento)
+	if err != nil {
+		return nil, err
+	}
+	ubicacion.Departamento = &dep.Nombre
+
+	if distrito == "" {
+		return ubicacion, nil
+	}
+	if len(dep.Distritos) == 0 {
+		ubicacion.Distrito = &distrito
+		return ubicacion, nil
+	}
+	for i := range dep.Distritos {
+		if clave(dep.Distritos[i]) == clave(distrito) {
+			ubicacion.Distrito = &dep.Distritos[i]
+			return ubicacion, nil
+		}
+	}
+
+	return nil, ErrDistritoNoEncontrado
+}
+
+func (s *Service) provincia(nombre string) (*Provincia, error) {
+	buscada := clave(nombre)
+	if alias, ok := aliasProvincias[buscada]; ok {
+		buscada = clave(alias)
+	}
+
+	prov, ok := s.indice[buscada]
+	if !ok {
+		return nil, ErrProvinciaNoEncontrada
+	}
+	return prov, nil
+}
+
+func buscarDepartamento(prov *Provincia, nombre string) (*Departamento, error) {
+	buscado := clave(nombre)
+	for i := range prov.Departamentos {
+		if clave(prov.Departamentos[i].Nombre) == buscado {
+			return &prov.Departamentos[i], nil
+		}
+	}
+	return nil, ErrDepartamentoNoEncontrado
+}
+
+// clave normaliza un nombre para compararlo: minúsculas, sin tildes y sin espacios de más
+func clave(nombre string) string {
+	return sinAcentos.Replace(strings.Join(strings.Fields(strings.ToLower(nombre)), " "))
+}
@@ -0,0 +1,228 @@
+// RUTA: coviar-backend/internal/indicador/handler.go
+package indicador
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/auth"
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Handler maneja las peticiones HTTP del catálogo de indicadores
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetCatalogoVigente maneja GET /api/indicadores - Catálogo publicado con capítulos, indicadores y niveles
+func (h *Handler) GetCatalogoVigente(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	catalogo, err := h.service.GetCatalogoVigente()
+	if err != nil {
+		log.Printf("Error al obtener catálogo vigente: %v", err)
+		sendServiceError(w, err, "Error al obtener catálogo", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, catalogo)
+}
+
+// ListVersiones maneja GET /api/catalogo/versiones
+func (h *Handler) ListVersiones(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	versiones, err := h.service.GetVersiones()
+	if err != nil {
+		log.Printf("Error al obtener versiones del catálogo: %v", err)
+		sendError(w, "Error al obtener versiones del catálogo", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, versiones)
+}
+
+// CreateVersion maneja POST /api/catalogo/versiones - Crear una versión en borrador
+func (h *Handler) CreateVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Nombre        string `json:"nombre"`
+		IdVersionBase *int   `json:"idVersionBase"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	version, err := h.service.CrearBorrador(body.Nombre, body.IdVersionBase)
+	if err != nil {
+		log.Printf("Error al crear versión del catálogo: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	sendSuccess(w, version)
+}
+
+// Route despacha las rutas que cuelgan de /api/catalogo/versiones/{id}
+func (h *Handler) Route(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extraer ID y acción de la URL
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/catalogo/versiones/"), "/")
+	parts := strings.Split(path, "/")
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		sendError(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.GetCatalogo(w, r, id)
+	case len(parts) == 2 && parts[1] == "publicar" && r.Method == http.MethodPost && esAdmin(r):
+		h.PublicarVersion(w, r, id)
+	case len(parts) == 2 && parts[1] == "retirar" && r.Method == http.MethodPost && esAdmin(r):
+		h.RetirarVersion(w, r, id)
+	case len(parts) == 2 && parts[1] == "vigencia" && r.Method == http.MethodPut && esAdmin(r):
+		h.UpdateVigencia(w, r, id)
+	case len(parts) == 2 && (parts[1] == "publicar" || parts[1] == "retirar") && r.Method == http.MethodPost,
+		len(parts) == 2 && parts[1] == "vigencia" && r.Method == http.MethodPut:
+		sendError(w, "Acceso denegado", http.StatusForbidden)
+	default:
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+// GetCatalogo maneja GET /api/catalogo/versiones/{id} - Árbol completo de una versión.
+// Las versiones en borrador solo son visibles para administradores.
+func (h *Handler) GetCatalogo(w http.ResponseWriter, r *http.Request, id int) {
+	catalogo, err := h.service.GetCatalogo(id)
+	if err != nil {
+		log.Printf("Error al obtener catálogo: %v", err)
+		sendServiceError(w, err, "Error al obtener catálogo", http.StatusInternalServerError)
+		return
+	}
+
+	if catalogo.Version.Estado == domain.VersionBorrador && !esAdmin(r) {
+		sendError(w, ErrVersionNoEncontrada.Error(), http.StatusNotFound)
+		return
+	}
+
+	sendSuccess(w, catalogo)
+}
+
+// PublicarVersion maneja POST /api/catalogo/versiones/{id}/publicar
+func (h *Handler) PublicarVersion(w http.ResponseWriter, r *http.Request, id int) {
+	version, err := h.service.Publicar(id)
+	if err != nil {
+		log.Printf("Error al publicar versión del catálogo: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, version)
+}
+
+// RetirarVersion maneja POST /api/catalogo/versiones/{id}/retirar
+func (h *Handler) RetirarVersion(w http.ResponseWriter, r *http.Request, id int) {
+	version, err := h.service.Retirar(id)
+	if err != nil {
+		log.Printf("Error al retirar versión del catálogo: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, version)
+}
+
+// UpdateVigencia maneja PUT /api/catalogo/versiones/{id}/vigencia
+func (h *Handler) UpdateVigencia(w http.ResponseWriter, r *http.Request, id int) {
+	var body struct {
+		MesesVigencia int `json:"meses_vigencia"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	version, err := h.service.ActualizarVigencia(id, body.MesesVigencia)
+	if err != nil {
+		log.Printf("Error al actualizar vigencia de la versión: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, version)
+}
+
+func esAdmin(r *http.Request) bool {
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	return ok && claims != nil && claims.Rol == "admin"
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// sendServiceError traduce los errores de negocio del servicio al código HTTP adecuado
+func sendServiceError(w http.ResponseWriter, err error, fallback string, fallbackStatus int) {
+	switch {
+	case errors.Is(err, ErrVersionNoEncontrada), errors.Is(err, ErrSinVersionPublicada):
+		sendError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrVersionNoBorrador), errors.Is(err, ErrVersionNoPublicada), errors.Is(err, ErrVersionNoEditable):
+		sendError(w, err.Error(), http.StatusConflict)
+	default:
+		sendError(w, fallback, fallbackStatus)
+	}
+}
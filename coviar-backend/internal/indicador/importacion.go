@@ -0,0 +1,353 @@
+// RUTA: coviar-backend/internal/indicador/importacion.go
+package indicador
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Tipos de cambio detectados al importar un catálogo
+const (
+	CambioAlta         = "alta"
+	CambioModificacion = "modificacion"
+	CambioBaja         = "baja"
+)
+
+// Cambio describe una diferencia entre el catálogo guardado y el importado
+type Cambio struct {
+	Tipo     string `json:"tipo"`
+	Elemento string `json:"elemento"` // capitulo, indicador o nivel
+	Clave    string `json:"clave"`    // número de capítulo, código de indicador o código/nivel
+	Antes    string `json:"antes,omitempty"`
+	Despues  string `json:"despues,omitempty"`
+}
+
+func (c Cambio) String() string {
+	switch c.Tipo {
+	case CambioAlta:
+		return fmt.Sprintf("+ %s %s: %s", c.Elemento, c.Clave, resumir(c.Despues))
+	case CambioBaja:
+		return fmt.Sprintf("- %s %s: %s", c.Elemento, c.Clave, resumir(c.Antes))
+	default:
+		return fmt.Sprintf("~ %s %s: %q -> %q", c.Elemento, c.Clave, resumir(c.Antes), resumir(c.Despues))
+	}
+}
+
+// Diferencias compara dos catálogos y devuelve los cambios necesarios para pasar de
+// actual a nuevo. Los capítulos se identifican por número, los indicadores por código
+// y los niveles por código de indicador y nivel. actual puede ser nil (catálogo vacío).
+func Diferencias(actual, nuevo *domain.Catalogo) []Cambio {
+	var cambios []Cambio
+
+	capitulosActuales := make(map[int]domain.Capitulo)
+	indicadoresActuales := make(map[string]domain.Indicador)
+	capituloActual := make(map[string]int) // código de indicador -> número de capítulo
+	if actual != nil {
+		for _, capitulo := range actual.Capitulos {
+			capitulosActuales[capitulo.Numero] = capitulo
+			for _, indicador := range capitulo.Indicadores {
+				indicadoresActuales[indicador.Codigo] = indicador
+				capituloActual[indicador.Codigo] = capitulo.Numero
+			}
+		}
+	}
+
+	capitulosNuevos := make(map[int]bool)
+	indicadoresNuevos := make(map[string]bool)
+
+	for _, capitulo := range nuevo.Capitulos {
+		capitulosNuevos[capitulo.Numero] = true
+		clave := fmt.Sprintf("%d", capitulo.Numero)
+
+		if previo, ok := capitulosActuales[capitulo.Numero]; !ok {
+			cambios = append(cambios, Cambio{Tipo: CambioAlta, Elemento: "capitulo", Clave: clave, Despues: capitulo.Nombre})
+		} else if previo.Nombre != capitulo.Nombre {
+			cambios = append(cambios, Cambio{Tipo: CambioModificacion, Elemento: "capitulo", Clave: clave, Antes: previo.Nombre, Despues: capitulo.Nombre})
+		}
+
+		for _, indicador := range capitulo.Indicadores {
+			indicadoresNuevos[indicador.Codigo] = true
+			previo, ok := indicadoresActuales[indicador.Codigo]
+			if !ok {
+				cambios = append(cambios, Cambio{Tipo: CambioAlta, Elemento: "indicador", Clave: indicador.Codigo, Despues: indicador.Nombre})
+				for _, nivel := range indicador.Niveles {
+					cambios = append(cambios, Cambio{Tipo: CambioAlta, Elemento: "nivel", Clave: claveNivel(indicador.Codigo, nivel.Nivel), Despues: nivel.Descripcion})
+				}
+				continue
+			}
+
+			if previo.Nombre != indicador.Nombre || textoOpcional(previo.Descripcion) != textoOpcional(indicador.Descripcion) {
+				cambios = append(cambios, Cambio{Tipo: CambioModificacion, Elemento: "indicador", Clave: indicador.Codigo, Antes: previo.Nombre, Despues: indicador.Nombre})
+			}
+			if capituloActual[indicador.Codigo] != capitulo.Numero {
+				cambios = append(cambios, Cambio{Tipo: CambioModificacion, Elemento: "indicador", Clave: indicador.Codigo,
+					Antes: fmt.Sprintf("capítulo %d", capituloActual[indicador.Codigo]), Despues: fmt.Sprintf("capítulo %d", capitulo.Numero)})
+			}
+
+			nivelesPrevios := make(map[int]domain.NivelIndicador)
+			for _, nivel := range previo.Niveles {
+				nivelesPrevios[nivel.Nivel] = nivel
+			}
+			nivelesNuevos := make(map[int]bool)
+			for _, nivel := range indicador.Niveles {
+				nivelesNuevos[nivel.Nivel] = true
+				clave := claveNivel(indicador.Codigo, nivel.Nivel)
+				if anterior, ok := nivelesPrevios[nivel.Nivel]; !ok {
+					cambios = append(cambios, Cambio{Tipo: CambioAlta, Elemento: "nivel", Clave: clave, Despues: nivel.Descripcion})
+				} else if anterior.Descripcion != nivel.Descripcion {
+					cambios = append(cambios, Cambio{Tipo: CambioModificacion, Elemento: "nivel", Clave: clave, Antes: anterior.Descripcion, Despues: nivel.Descripcion})
+				}
+			}
+			for _, nivel := range previo.Niveles {
+				if !nivelesNuevos[nivel.Nivel] {
+					cambios = append(cambios, Cambio{Tipo: CambioBaja, Elemento: "nivel", Clave: claveNivel(indicador.Codigo, nivel.Nivel), Antes: nivel.Descripcion})
+				}
+			}
+		}
+	}
+
+	if actual != nil {
+		for _, capitulo := range actual.Capitulos {
+			for _, indicador := range capitulo.Indicadores {
+				if !indicadoresNuevos[indicador.Codigo] {
+					cambios = append(cambios, Cambio{Tipo: CambioBaja, Elemento: "indicador", Clave: indicador.Codigo, Antes: indicador.Nombre})
+				}
+			}
+			if !capitulosNuevos[capitulo.Numero] {
+				cambios = append(cambios, Cambio{Tipo: CambioBaja, Elemento: "capitulo", Clave: fmt.Sprintf("%d", capitulo.Numero), Antes: capitulo.Nombre})
+			}
+		}
+	}
+
+	return cambios
+}
+
+// Importar sincroniza una versión en borrador con el catálogo dado: crea lo que falta,
+// actualiza lo que cambió y elimina lo que ya no está. Importar dos veces el mismo
+// catálogo no produce cambios. Si aplicar es false solo devuelve la vista previa.
+func (s *Service) Importar(idVersion int, nuevo *domain.Catalogo, aplicar bool) ([]Cambio, error) {
+	if err := validarCatalogo(nuevo); err != nil {
+		return nil, err
+	}
+
+	actual, err := s.GetCatalogo(idVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if actual.Version.Estado != domain.VersionBorrador {
+		return nil, ErrVersionNoEditable
+	}
+
+	cambios := Diferencias(actual, nuevo)
+	if !aplicar || len(cambios) == 0 {
+		return cambios, nil
+	}
+
+	if err := s.sincronizar(actual, nuevo); err != nil {
+		return nil, fmt.Errorf("error al importar el catálogo: %w", err)
+	}
+
+	return cambios, nil
+}
+
+// sincronizar aplica en la base de datos el contenido de nuevo sobre actual
+func (s *Service) sincronizar(actual, nuevo *domain.Catalogo) error {
+	capitulosActuales := make(map[int]domain.Capitulo)
+	indicadoresActuales := make(map[string]domain.Indicador)
+	for _, capitulo := range actual.Capitulos {
+		capitulosActuales[capitulo.Numero] = capitulo
+		for _, indicador := range capitulo.Indicadores {
+			indicadoresActuales[indicador.Codigo] = indicador
+		}
+	}
+
+	capitulosNuevos := make(map[int]bool)
+	indicadoresNuevos := make(map[string]bool)
+
+	for _, capituloNuevo := range nuevo.Capitulos {
+		capitulosNuevos[capituloNuevo.Numero] = true
+
+		capitulo, ok := capitulosActuales[capituloNuevo.Numero]
+		if !ok {
+			capitulo = domain.Capitulo{IdVersion: actual.Version.IdVersion, Numero: capituloNuevo.Numero, Nombre: capituloNuevo.Nombre}
+			if err := s.repo.CreateCapitulo(&capitulo); err != nil {
+				return err
+			}
+		} else if capitulo.Nombre != capituloNuevo.Nombre {
+			capitulo.Nombre = capituloNuevo.Nombre
+			if err := s.repo.UpdateCapitulo(&capitulo); err != nil {
+				return err
+			}
+		}
+
+		var altas []domain.Indicador
+		for _, indicadorNuevo := range capituloNuevo.Indicadores {
+			indicadoresNuevos[indicadorNuevo.Codigo] = true
+
+			previo, ok := indicadoresActuales[indicadorNuevo.Codigo]
+			if !ok {
+				altas = append(altas, domain.Indicador{
+					IdCapitulo:  capitulo.IdCapitulo,
+					Codigo:      indicadorNuevo.Codigo,
+					Nombre:      indicadorNuevo.Nombre,
+					Descripcion: indicadorNuevo.Descripcion,
+				})
+				continue
+			}
+
+			if previo.IdCapitulo != capitulo.IdCapitulo || previo.Nombre != indicadorNuevo.Nombre ||
+				textoOpcional(previo.Descripcion) != textoOpcional(indicadorNuevo.Descripcion) {
+				previo.IdCapitulo = capitulo.IdCapitulo
+				previo.Nombre = indicadorNuevo.Nombre
+				previo.Descripcion = indicadorNuevo.Descripcion
+				if err := s.repo.UpdateIndicador(&previo); err != nil {
+					return err
+				}
+			}
+
+			if err := s.sincronizarNiveles(previo, indicadorNuevo.Niveles); err != nil {
+				return err
+			}
+		}
+
+		creados, err := s.repo.CreateIndicadores(altas)
+		if err != nil {
+			return err
+		}
+
+		idPorCodigo := make(map[string]int, len(creados))
+		for _, creado := range creados {
+			idPorCodigo[creado.Codigo] = creado.IdIndicador
+		}
+
+		var niveles []domain.NivelIndicador
+		for _, indicadorNuevo := range capituloNuevo.Indicadores {
+			idIndicador, ok := idPorCodigo[indicadorNuevo.Codigo]
+			if !ok {
+				continue
+			}
+			for _, nivel := range indicadorNuevo.Niveles {
+				niveles = append(niveles, domain.NivelIndicador{IdIndicador: idIndicador, Nivel: nivel.Nivel, Descripcion: nivel.Descripcion})
+			}
+		}
+		if err := s.repo.CreateNiveles(niveles); err != nil {
+			return err
+		}
+	}
+
+	for _, capitulo := range actual.Capitulos {
+		for _, indicador := range capitulo.Indicadores {
+			if !indicadoresNuevos[indicador.Codigo] {
+				if err := s.repo.DeleteIndicador(indicador.IdIndicador); err != nil {
+					return err
+				}
+			}
+		}
+		if !capitulosNuevos[capitulo.Numero] {
+			if err := s.repo.DeleteCapitulo(capitulo.IdCapitulo); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) sincronizarNiveles(indicador domain.Indicador, nuevos []domain.NivelIndicador) error {
+	previos := make(map[int]domain.NivelIndicador)
+	for _, nivel := range indicador.Niveles {
+		previos[nivel.Nivel] = nivel
+	}
+
+	vistos := make(map[int]bool)
+	var altas []domain.NivelIndicador
+	for _, nivel := range nuevos {
+		vistos[nivel.Nivel] = true
+		previo, ok := previos[nivel.Nivel]
+		if !ok {
+			altas = append(altas, domain.NivelIndicador{IdIndicador: indicador.IdIndicador, Nivel: nivel.Nivel, Descripcion: nivel.Descripcion})
+			continue
+		}
+		if previo.Descripcion != nivel.Descripcion {
+			previo.Descripcion = nivel.Descripcion
+			if err := s.repo.UpdateNivel(&previo); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, nivel := range indicador.Niveles {
+		if !vistos[nivel.Nivel] {
+			if err := s.repo.DeleteNivel(nivel.IdNivelIndicador); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.repo.CreateNiveles(altas)
+}
+
+// validarCatalogo verifica que el catálogo a importar sea consistente
+func validarCatalogo(catalogo *domain.Catalogo) error {
+	if len(catalogo.Capitulos) == 0 {
+		return fmt.Errorf("el catálogo no tiene capítulos")
+	}
+
+	capitulos := make(map[int]bool)
+	codigos := make(map[string]bool)
+	for _, capitulo := range catalogo.Capitulos {
+		if capitulo.Numero <= 0 {
+			return fmt.Errorf("número de capítulo inválido: %d", capitulo.Numero)
+		}
+		if capitulos[capitulo.Numero] {
+			return fmt.Errorf("capítulo %d repetido", capitulo.Numero)
+		}
+		capitulos[capitulo.Numero] = true
+
+		for _, indicador := range capitulo.Indicadores {
+			if strings.TrimSpace(indicador.Codigo) == "" || strings.TrimSpace(indicador.Nombre) == "" {
+				return fmt.Errorf("el capítulo %d tiene un indicador sin código o nombre", capitulo.Numero)
+			}
+			if codigos[indicador.Codigo] {
+				return fmt.Errorf("indicador %s repetido", indicador.Codigo)
+			}
+			codigos[indicador.Codigo] = true
+
+			niveles := make(map[int]bool)
+			for _, nivel := range indicador.Niveles {
+				if nivel.Nivel < domain.NivelMinimo || nivel.Nivel > domain.NivelMaximo {
+					return fmt.Errorf("indicador %s: nivel %d fuera de rango", indicador.Codigo, nivel.Nivel)
+				}
+				if niveles[nivel.Nivel] {
+					return fmt.Errorf("indicador %s: nivel %d repetido", indicador.Codigo, nivel.Nivel)
+				}
+				niveles[nivel.Nivel] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+func claveNivel(codigo string, nivel int) string {
+	return fmt.Sprintf("%s/%d", codigo, nivel)
+}
+
+func textoOpcional(texto *string) string {
+	if texto == nil {
+		return ""
+	}
+	return *texto
+}
+
+func resumir(texto string) string {
+	const maximo = 60
+	runas := []rune(texto)
+	if len(runas) <= maximo {
+		return texto
+	}
+	return string(runas[:maximo]) + "…"
+}
@@ -0,0 +1,383 @@
+// RUTA: coviar-backend/internal/indicador/repository.go
+package indicador
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/platform/database"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// ordenAscendente ordena los resultados de menor a mayor
+var ordenAscendente = postgrest.OrderOpts{Ascending: true}
+
+// Repository maneja el acceso a datos del catálogo de indicadores
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindVersiones obtiene todas las versiones del catálogo, de la más reciente a la más antigua
+func (r *Repository) FindVersiones() ([]domain.VersionCatalogo, error) {
+	data, _, err := r.db.From("version_catalogo").
+		Select("*", "", false).
+		Order("idVersion", nil).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var versiones []domain.VersionCatalogo
+	if err := json.Unmarshal(data, &versiones); err != nil {
+		return nil, err
+	}
+
+	return versiones, nil
+}
+
+// FindVersionByID obtiene una versión del catálogo por ID
+func (r *Repository) FindVersionByID(id int) (*domain.VersionCatalogo, error) {
+	data, _, err := r.db.From("version_catalogo").
+		Select("*", "", false).
+		Eq("idVersion", fmt.Sprintf("%d", id)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var versiones []domain.VersionCatalogo
+	if err := json.Unmarshal(data, &versiones); err != nil {
+		return nil, err
+	}
+
+	if len(versiones) == 0 {
+		return nil, ErrVersionNoEncontrada
+	}
+
+	return &versiones[0], nil
+}
+
+// FindVersionPublicada obtiene la versión publicada del catálogo
+func (r *Repository) FindVersionPublicada() (*domain.VersionCatalogo, error) {
+	data, _, err := r.db.From("version_catalogo").
+		Select("*", "", false).
+		Eq("estado", domain.VersionPublicada).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var versiones []domain.VersionCatalogo
+	if err := json.Unmarshal(data, &versiones); err != nil {
+		return nil, err
+	}
+
+	if len(versiones) == 0 {
+		return nil, ErrSinVersionPublicada
+	}
+
+	return &versiones[0], nil
+}
+
+// CreateVersion crea una nueva versión del catálogo
+func (r *Repository) CreateVersion(version *domain.VersionCatalogo) error {
+	versionMap := map[string]interface{}{
+		"nombre":         version.Nombre,
+		"estado":         version.Estado,
+		"fecha_creacion": time.Now().Format(time.RFC3339),
+		"meses_vigencia": version.MesesVigencia,
+	}
+
+	data, _, err := r.db.From("version_catalogo").
+		Insert(versionMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []domain.VersionCatalogo
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*version = result[0]
+	}
+
+	return nil
+}
+
+// UpdateVersionEstado actualiza el estado y las fechas de publicación y retiro de una
+// versión, solo si su estado sigue siendo estadoAnterior. Devuelve false si otra petición
+// lo cambió entretanto. El trigger de scripts/024 actualiza la vigencia de los indicadores.
+func (r *Repository) UpdateVersionEstado(version *domain.VersionCatalogo, estadoAnterior string) (bool, error) {
+	updateMap := map[string]interface{}{
+		"estado":            version.Estado,
+		"fecha_publicacion": version.FechaPublicacion,
+		"fecha_retiro":      version.FechaRetiro,
+	}
+
+	data, _, err := r.db.From("version_catalogo").
+		Update(updateMap, "", "").
+		Eq("idVersion", fmt.Sprintf("%d", version.IdVersion)).
+		Eq("estado", estadoAnterior).
+		Execute()
+
+	if err != nil {
+		return false, err
+	}
+
+	var result []domain.VersionCatalogo
+	if err := json.Unmarshal(data, &result); err != nil {
+		return false, err
+	}
+
+	return len(result) > 0, nil
+}
+
+// UpdateMesesVigencia actualiza la validez de las evaluaciones aprobadas con una versión
+func (r *Repository) UpdateMesesVigencia(idVersion int, meses int) error {
+	_, _, err := r.db.From("version_catalogo").
+		Update(map[string]interface{}{"meses_vigencia": meses}, "", "").
+		Eq("idVersion", fmt.Sprintf("%d", idVersion)).
+		Execute()
+
+	return err
+}
+
+// FindCapitulos obtiene los capítulos de una versión ordenados por número
+func (r *Repository) FindCapitulos(idVersion int) ([]domain.Capitulo, error) {
+	data, _, err := r.db.From("capitulo").
+		Select("*", "", false).
+		Eq("idVersion", fmt.Sprintf("%d", idVersion)).
+		Order("numero", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var capitulos []domain.Capitulo
+	if err := json.Unmarshal(data, &capitulos); err != nil {
+		return nil, err
+	}
+
+	return capitulos, nil
+}
+
+// CreateCapitulo crea un capítulo dentro de una versión
+func (r *Repository) CreateCapitulo(capitulo *domain.Capitulo) error {
+	capituloMap := map[string]interface{}{
+		"idVersion": capitulo.IdVersion,
+		"numero":    capitulo.Numero,
+		"nombre":    capitulo.Nombre,
+	}
+
+	data, _, err := r.db.From("capitulo").
+		Insert(capituloMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []domain.Capitulo
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*capitulo = result[0]
+	}
+
+	return nil
+}
+
+// FindIndicadoresByCapitulos obtiene los indicadores de los capítulos dados ordenados por código
+func (r *Repository) FindIndicadoresByCapitulos(idsCapitulo []int) ([]domain.Indicador, error) {
+	if len(idsCapitulo) == 0 {
+		return nil, nil
+	}
+
+	data, _, err := r.db.From("indicador").
+		Select("*", "", false).
+		In("idCapitulo", database.ToStrings(idsCapitulo)).
+		Order("codigo", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var indicadores []domain.Indicador
+	if err := json.Unmarshal(data, &indicadores); err != nil {
+		return nil, err
+	}
+
+	return indicadores, nil
+}
+
+// CreateIndicadores crea en bloque los indicadores de un capítulo y devuelve las filas creadas
+func (r *Repository) CreateIndicadores(indicadores []domain.Indicador) ([]domain.Indicador, error) {
+	if len(indicadores) == 0 {
+		return nil, nil
+	}
+
+	filas := make([]map[string]interface{}, len(indicadores))
+	for i, indicador := range indicadores {
+		filas[i] = map[string]interface{}{
+			"idCapitulo":  indicador.IdCapitulo,
+			"codigo":      indicador.Codigo,
+			"nombre":      indicador.Nombre,
+			"descripcion": indicador.Descripcion,
+			"vigente":     indicador.Vigente,
+		}
+	}
+
+	data, _, err := r.db.From("indicador").
+		Insert(filas, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []domain.Indicador
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindNiveles obtiene las descripciones de niveles de los indicadores dados
+func (r *Repository) FindNiveles(idsIndicador []int) ([]domain.NivelIndicador, error) {
+	if len(idsIndicador) == 0 {
+		return nil, nil
+	}
+
+	data, _, err := r.db.From("nivel_indicador").
+		Select("*", "", false).
+		In("idIndicador", database.ToStrings(idsIndicador)).
+		Order("nivel", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var niveles []domain.NivelIndicador
+	if err := json.Unmarshal(data, &niveles); err != nil {
+		return nil, err
+	}
+
+	return niveles, nil
+}
+
+// CreateNiveles crea en bloque descripciones de niveles
+func (r *Repository) CreateNiveles(niveles []domain.NivelIndicador) error {
+	if len(niveles) == 0 {
+		return nil
+	}
+
+	filas := make([]map[string]interface{}, len(niveles))
+	for i, nivel := range niveles {
+		filas[i] = map[string]interface{}{
+			"idIndicador": nivel.IdIndicador,
+			"nivel":       nivel.Nivel,
+			"descripcion": nivel.Descripcion,
+		}
+	}
+
+	_, _, err := r.db.From("nivel_indicador").
+		Insert(filas, false, "", "minimal", "").
+		Execute()
+
+	return err
+}
+
+// UpdateCapitulo actualiza el nombre de un capítulo
+func (r *Repository) UpdateCapitulo(capitulo *domain.Capitulo) error {
+	updateMap := map[string]interface{}{
+		"nombre": capitulo.Nombre,
+	}
+
+	_, _, err := r.db.From("capitulo").
+		Update(updateMap, "minimal", "").
+		Eq("idCapitulo", fmt.Sprintf("%d", capitulo.IdCapitulo)).
+		Execute()
+
+	return err
+}
+
+// DeleteCapitulo elimina un capítulo junto con sus indicadores y niveles
+func (r *Repository) DeleteCapitulo(idCapitulo int) error {
+	_, _, err := r.db.From("capitulo").
+		Delete("minimal", "").
+		Eq("idCapitulo", fmt.Sprintf("%d", idCapitulo)).
+		Execute()
+
+	return err
+}
+
+// UpdateIndicador actualiza el capítulo, el nombre y la descripción de un indicador
+func (r *Repository) UpdateIndicador(indicador *domain.Indicador) error {
+	updateMap := map[string]interface{}{
+		"idCapitulo":  indicador.IdCapitulo,
+		"nombre":      indicador.Nombre,
+		"descripcion": indicador.Descripcion,
+	}
+
+	_, _, err := r.db.From("indicador").
+		Update(updateMap, "minimal", "").
+		Eq("idIndicador", fmt.Sprintf("%d", indicador.IdIndicador)).
+		Execute()
+
+	return err
+}
+
+// DeleteIndicador elimina un indicador junto con sus niveles
+func (r *Repository) DeleteIndicador(idIndicador int) error {
+	_, _, err := r.db.From("indicador").
+		Delete("minimal", "").
+		Eq("idIndicador", fmt.Sprintf("%d", idIndicador)).
+		Execute()
+
+	return err
+}
+
+// UpdateNivel actualiza la descripción de un nivel de indicador
+func (r *Repository) UpdateNivel(nivel *domain.NivelIndicador) error {
+	updateMap := map[string]interface{}{
+		"descripcion": nivel.Descripcion,
+	}
+
+	_, _, err := r.db.From("nivel_indicador").
+		Update(updateMap, "minimal", "").
+		Eq("idNivelIndicador", fmt.Sprintf("%d", nivel.IdNivelIndicador)).
+		Execute()
+
+	return err
+}
+
+// DeleteNivel elimina la descripción de un nivel de indicador
+func (r *Repository) DeleteNivel(idNivelIndicador int) error {
+	_, _, err := r.db.From("nivel_indicador").
+		Delete("minimal", "").
+		Eq("idNivelIndicador", fmt.Sprintf("%d", idNivelIndicador)).
+		Execute()
+
+	return err
+}
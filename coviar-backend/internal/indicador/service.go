@@ -0,0 +1,325 @@
+// RUTA: coviar-backend/internal/indicador/service.go
+package indicador
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/segmento"
+)
+
+// Errores de negocio que el handler traduce a códigos HTTP
+var (
+	ErrVersionNoEncontrada = errors.New("versión del catálogo no encontrada")
+	ErrSinVersionPublicada = errors.New("no hay una versión publicada del catálogo")
+	ErrVersionNoBorrador   = errors.New("solo se pueden publicar versiones en borrador")
+	ErrVersionNoPublicada  = errors.New("solo se pueden retirar versiones publicadas")
+	ErrVersionNoEditable   = errors.New("solo se pueden modificar versiones en borrador")
+)
+
+// Service contiene la lógica de negocio del catálogo versionado de indicadores
+type Service struct {
+	repo      *Repository
+	segmentos *segmento.Service
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, segmentoService *segmento.Service) *Service {
+	return &Service{repo: repo, segmentos: segmentoService}
+}
+
+// GetVersiones obtiene todas las versiones del catálogo
+func (s *Service) GetVersiones() ([]domain.VersionCatalogo, error) {
+	return s.repo.FindVersiones()
+}
+
+// GetVersion obtiene una versión del catálogo por ID
+func (s *Service) GetVersion(id int) (*domain.VersionCatalogo, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+
+	return s.repo.FindVersionByID(id)
+}
+
+// GetVersionPublicada obtiene la versión del catálogo con la que se inician las evaluaciones nuevas
+func (s *Service) GetVersionPublicada() (*domain.VersionCatalogo, error) {
+	return s.repo.FindVersionPublicada()
+}
+
+// GetCatalogo arma el árbol completo (capítulos, indicadores y niveles) de una versión
+func (s *Service) GetCatalogo(idVersion int) (*domain.Catalogo, error) {
+	version, err := s.GetVersion(idVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.armarCatalogo(version)
+}
+
+// GetCatalogoVigente arma el árbol completo de la versión publicada
+func (s *Service) GetCatalogoVigente() (*domain.Catalogo, error) {
+	version, err := s.repo.FindVersionPublicada()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.armarCatalogo(version)
+}
+
+// GetIndicadoresVersion obtiene todos los indicadores de una versión con sus niveles,
+// incluidos los que ya no están vigentes
+func (s *Service) GetIndicadoresVersion(idVersion int) ([]domain.Indicador, error) {
+	catalogo, err := s.GetCatalogo(idVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var indicadores []domain.Indicador
+	for _, capitulo := range catalogo.Capitulos {
+		indicadores = append(indicadores, capitulo.Indicadores...)
+	}
+
+	return indicadores, nil
+}
+
+// CrearBorrador crea una versión en borrador copiando capítulos, indicadores, niveles
+// y reglas de segmento de una versión base (por defecto, la publicada)
+func (s *Service) CrearBorrador(nombre string, idVersionBase *int) (*domain.VersionCatalogo, error) {
+	nombre = strings.TrimSpace(nombre)
+	if nombre == "" {
+		return nil, fmt.Errorf("el nombre es requerido")
+	}
+
+	var base *domain.Catalogo
+	if idVersionBase != nil {
+		catalogo, err := s.GetCatalogo(*idVersionBase)
+		if err != nil {
+			return nil, err
+		}
+		base = catalogo
+	} else {
+		catalogo, err := s.GetCatalogoVigente()
+		if err != nil && !errors.Is(err, ErrSinVersionPublicada) {
+			return nil, err
+		}
+		base = catalogo
+	}
+
+	version := &domain.VersionCatalogo{
+		Nombre:        nombre,
+		Estado:        domain.VersionBorrador,
+		MesesVigencia: domain.MesesVigenciaPredeterminado,
+	}
+	if base != nil && base.Version.MesesVigencia > 0 {
+		version.MesesVigencia = base.Version.MesesVigencia
+	}
+	if err := s.repo.CreateVersion(version); err != nil {
+		return nil, err
+	}
+
+	if base == nil {
+		return version, nil
+	}
+
+	if err := s.copiarContenido(base, version.IdVersion); err != nil {
+		return nil, fmt.Errorf("error al copiar la versión base: %w", err)
+	}
+
+	return version, nil
+}
+
+// ActualizarVigencia cambia los meses de validez de las evaluaciones que se aprueben con
+// una versión. Las ya aprobadas conservan la fecha de vencimiento que se les fijó.
+func (s *Service) ActualizarVigencia(id int, meses int) (*domain.VersionCatalogo, error) {
+	if meses <= 0 {
+		return nil, fmt.Errorf("los meses de vigencia deben ser mayores a cero")
+	}
+
+	version, err := s.GetVersion(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateMesesVigencia(id, meses); err != nil {
+		return nil, err
+	}
+
+	version.MesesVigencia = meses
+	return version, nil
+}
+
+// Publicar publica una versión en borrador. La versión publicada anterior pasa a
+// retirada y sus indicadores dejan de estar vigentes.
+func (s *Service) Publicar(id int) (*domain.VersionCatalogo, error) {
+	version, err := s.GetVersion(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.Estado != domain.VersionBorrador {
+		return nil, ErrVersionNoBorrador
+	}
+
+	capitulos, err := s.repo.FindCapitulos(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(capitulos) == 0 {
+		return nil, fmt.Errorf("la versión no tiene capítulos cargados")
+	}
+
+	// El cambio de estado retira la versión anterior y actualiza la vigencia de los
+	// indicadores en la misma operación (scripts/024)
+	ahora := time.Now().Format(time.RFC3339)
+	version.Estado = domain.VersionPublicada
+	version.FechaPublicacion = &ahora
+
+	actualizada, err := s.repo.UpdateVersionEstado(version, domain.VersionBorrador)
+	if err != nil {
+		return nil, err
+	}
+	if !actualizada {
+		return nil, ErrVersionNoBorrador
+	}
+
+	return version, nil
+}
+
+// Retirar retira la versión publicada. Sus indicadores dejan de estar vigentes pero
+// se conservan para las evaluaciones que se iniciaron con ella.
+func (s *Service) Retirar(id int) (*domain.VersionCatalogo, error) {
+	version, err := s.GetVersion(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.Estado != domain.VersionPublicada {
+		return nil, ErrVersionNoPublicada
+	}
+
+	ahora := time.Now().Format(time.RFC3339)
+	version.Estado = domain.VersionRetirada
+	version.FechaRetiro = &ahora
+
+	actualizada, err := s.repo.UpdateVersionEstado(version, domain.VersionPublicada)
+	if err != nil {
+		return nil, err
+	}
+	if !actualizada {
+		return nil, ErrVersionNoPublicada
+	}
+
+	return version, nil
+}
+
+func (s *Service) armarCatalogo(version *domain.VersionCatalogo) (*domain.Catalogo, error) {
+	capitulos, err := s.repo.FindCapitulos(version.IdVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	indicadores, err := s.repo.FindIndicadoresByCapitulos(idsCapitulos(capitulos))
+	if err != nil {
+		return nil, err
+	}
+
+	idsIndicador := make([]int, len(indicadores))
+	for i, indicador := range indicadores {
+		idsIndicador[i] = indicador.IdIndicador
+	}
+
+	niveles, err := s.repo.FindNiveles(idsIndicador)
+	if err != nil {
+		return nil, err
+	}
+
+	nivelesPorIndicador := make(map[int][]domain.NivelIndicador)
+	for _, nivel := range niveles {
+		nivelesPorIndicador[nivel.IdIndicador] = append(nivelesPorIndicador[nivel.IdIndicador], nivel)
+	}
+
+	indicadoresPorCapitulo := make(map[int][]domain.Indicador)
+	for _, indicador := range indicadores {
+		indicador.Niveles = nivelesPorIndicador[indicador.IdIndicador]
+		indicadoresPorCapitulo[indicador.IdCapitulo] = append(indicadoresPorCapitulo[indicador.IdCapitulo], indicador)
+	}
+
+	for i := range capitulos {
+		indicadoresCapitulo := indicadoresPorCapitulo[capitulos[i].IdCapitulo]
+		sort.SliceStable(indicadoresCapitulo, func(a, b int) bool {
+			return domain.CompararCodigos(indicadoresCapitulo[a].Codigo, indicadoresCapitulo[b].Codigo) < 0
+		})
+		capitulos[i].Indicadores = indicadoresCapitulo
+	}
+
+	return &domain.Catalogo{Version: *version, Capitulos: capitulos}, nil
+}
+
+func (s *Service) copiarContenido(base *domain.Catalogo, idVersion int) error {
+	// Relación ID de indicador en la versión base -> ID en la versión nueva
+	nuevosIds := make(map[int]int)
+
+	for _, capituloBase := range base.Capitulos {
+		capitulo := domain.Capitulo{
+			IdVersion: idVersion,
+			Numero:    capituloBase.Numero,
+			Nombre:    capituloBase.Nombre,
+		}
+		if err := s.repo.CreateCapitulo(&capitulo); err != nil {
+			return err
+		}
+
+		copias := make([]domain.Indicador, len(capituloBase.Indicadores))
+		for i, indicador := range capituloBase.Indicadores {
+			copias[i] = domain.Indicador{
+				IdCapitulo:  capitulo.IdCapitulo,
+				Codigo:      indicador.Codigo,
+				Nombre:      indicador.Nombre,
+				Descripcion: indicador.Descripcion,
+				Vigente:     false, // pasa a vigente al publicar la versión
+			}
+		}
+
+		creados, err := s.repo.CreateIndicadores(copias)
+		if err != nil {
+			return err
+		}
+
+		idPorCodigo := make(map[string]int, len(creados))
+		for _, creado := range creados {
+			idPorCodigo[creado.Codigo] = creado.IdIndicador
+		}
+
+		var niveles []domain.NivelIndicador
+		for _, indicador := range capituloBase.Indicadores {
+			idNuevo := idPorCodigo[indicador.Codigo]
+			nuevosIds[indicador.IdIndicador] = idNuevo
+			for _, nivel := range indicador.Niveles {
+				niveles = append(niveles, domain.NivelIndicador{
+					IdIndicador: idNuevo,
+					Nivel:       nivel.Nivel,
+					Descripcion: nivel.Descripcion,
+				})
+			}
+		}
+
+		if err := s.repo.CreateNiveles(niveles); err != nil {
+			return err
+		}
+	}
+
+	return s.segmentos.CopiarReglas(nuevosIds)
+}
+
+func idsCapitulos(capitulos []domain.Capitulo) []int {
+	ids := make([]int, len(capitulos))
+	for i, capitulo := range capitulos {
+		ids[i] = capitulo.IdCapitulo
+	}
+	return ids
+}
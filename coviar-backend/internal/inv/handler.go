@@ -0,0 +1,146 @@
+// RUTA: coviar-backend/internal/inv/handler.go
+package inv
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"mime/multipart"
+	"net/http"
+)
+
+// Handler maneja las peticiones HTTP del registro del INV
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ImportarRegistro maneja POST /api/admin/registro-inv - Reemplaza el registro de
+// establecimientos del INV por el del archivo CSV (multipart, campo archivo) y devuelve
+// las observaciones de las bodegas contra el registro nuevo
+func (h *Handler) ImportarRegistro(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	archivo, ok := leerArchivo(w, r)
+	if !ok {
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	defer archivo.Close()
+
+	resultado, err := h.service.Importar(archivo)
+	if err != nil {
+		log.Printf("Error al importar el registro del INV: %v", err)
+		sendArchivoError(w, err, "Error al importar el registro del INV")
+		return
+	}
+
+	sendSuccess(w, resultado)
+}
+
+// Verificacion maneja /api/admin/registro-inv/verificacion:
+//   - GET compara los números de INV de todas las bodegas con el registro importado
+//   - POST los compara con el registro de un archivo CSV (multipart, campo archivo) sin
+//     importarlo, para revisar un registro nuevo antes de reemplazar el vigente
+func (h *Handler) Verificacion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		observaciones, err := h.service.Reverificar()
+		if err != nil {
+			log.Printf("Error al verificar las bodegas contra el registro del INV: %v", err)
+			sendError(w, "Error al verificar las bodegas", http.StatusInternalServerError)
+			return
+		}
+
+		sendSuccess(w, observaciones)
+	case http.MethodPost:
+		archivo, ok := leerArchivo(w, r)
+		if !ok {
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+		defer archivo.Close()
+
+		resultado, err := h.service.VerificarArchivo(archivo)
+		if err != nil {
+			log.Printf("Error al verificar las bodegas contra el archivo del INV: %v", err)
+			sendArchivoError(w, err, "Error al verificar las bodegas")
+			return
+		}
+
+		sendSuccess(w, resultado)
+	default:
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+// leerArchivo obtiene el archivo del registro del formulario multipart. Si no puede,
+// responde el error y devuelve false.
+func leerArchivo(w http.ResponseWriter, r *http.Request) (multipart.File, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, TamanoMaximo+(1<<20))
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			sendError(w, "El archivo supera el tamaño máximo de 20 MB", http.StatusRequestEntityTooLarge)
+			return nil, false
+		}
+		sendError(w, "Formulario inválido", http.StatusBadRequest)
+		return nil, false
+	}
+
+	archivo, _, err := r.FormFile("archivo")
+	if err != nil {
+		r.MultipartForm.RemoveAll()
+		sendError(w, "El archivo es requerido", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return archivo, true
+}
+
+// sendArchivoError informa los errores del archivo como 422 y el resto como error interno
+func sendArchivoError(w http.ResponseWriter, err error, fallback string) {
+	if errors.Is(err, ErrArchivoInvalido) {
+		sendError(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	sendError(w, fallback, http.StatusInternalServerError)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
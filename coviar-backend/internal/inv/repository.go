@@ -0,0 +1,124 @@
+// RUTA: coviar-backend/internal/inv/repository.go
+package inv
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/platform/database"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// filaRegistro es una fila del registro con el ID usado para leerlo por tandas
+type filaRegistro struct {
+	IdRegistro int `json:"idRegistro"`
+	domain.RegistroInv
+}
+
+// bodegaInv son los datos de una bodega que se comparan con el registro
+type bodegaInv struct {
+	IdBodega   int    `json:"idBodega"`
+	Nombre     string `json:"nombre"`
+	Cuit       int64  `json:"cuit"`
+	Inv        int    `json:"inv"`
+	ViñedosInv int    `json:"viñedos_inv"`
+}
+
+// Repository maneja el acceso al registro del INV importado
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// HayRegistro indica si ya se importó algún registro del INV
+func (r *Repository) HayRegistro() (bool, error) {
+	data, _, err := r.db.From("registro_inv").
+		Select("idRegistro", "", false).
+		Limit(1, "").
+		Execute()
+
+	if err != nil {
+		return false, err
+	}
+
+	var filas []filaRegistro
+	if err := json.Unmarshal(data, &filas); err != nil {
+		return false, err
+	}
+
+	return len(filas) > 0, nil
+}
+
+// FindByNumero obtiene el establecimiento con el número y tipo dados, o nil si no figura
+func (r *Repository) FindByNumero(numero int, tipo string) (*domain.RegistroInv, error) {
+	data, _, err := r.db.From("registro_inv").
+		Select("*", "", false).
+		Eq("numero", strconv.Itoa(numero)).
+		Eq("tipo", tipo).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var filas []filaRegistro
+	if err := json.Unmarshal(data, &filas); err != nil {
+		return nil, err
+	}
+
+	if len(filas) == 0 {
+		return nil, nil
+	}
+
+	return &filas[0].RegistroInv, nil
+}
+
+// FindAll obtiene el registro completo, leyéndolo por tandas
+func (r *Repository) FindAll() ([]domain.RegistroInv, error) {
+	filas, err := database.LeerPorTandas(func() *postgrest.FilterBuilder {
+		return r.db.From("registro_inv").Select("*", "", false)
+	}, "idRegistro", func(fila *filaRegistro) int { return fila.IdRegistro })
+
+	if err != nil {
+		return nil, err
+	}
+
+	registros := make([]domain.RegistroInv, len(filas))
+	for i, fila := range filas {
+		registros[i] = fila.RegistroInv
+	}
+
+	return registros, nil
+}
+
+// Reemplazar guarda el registro nuevo en lugar del anterior. El registro completo se
+// envía en una sola importación que la base aplica en una transacción (ver
+// scripts/022_importacion_inv.sql): si falla, el registro anterior queda intacto.
+func (r *Repository) Reemplazar(registros []domain.RegistroInv) error {
+	importacion := map[string]interface{}{
+		"cantidad":  len(registros),
+		"registros": registros,
+	}
+	if len(registros) > 0 {
+		importacion["fecha"] = registros[0].FechaImportacion
+	}
+
+	_, _, err := r.db.From("importacion_inv").
+		Insert(importacion, false, "", "minimal", "").
+		Execute()
+
+	return err
+}
+
+// FindBodegas obtiene los números de INV de todas las bodegas, leyéndolas por tandas
+func (r *Repository) FindBodegas() ([]bodegaInv, error) {
+	return database.LeerPorTandas(func() *postgrest.FilterBuilder {
+		return r.db.From("bodega").Select("*", "", false)
+	}, "idBodega", func(bod *bodegaInv) int { return bod.IdBodega })
+}
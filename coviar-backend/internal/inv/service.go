@@ -0,0 +1,360 @@
+// RUTA: coviar-backend/internal/inv/service.go
+package inv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// TamanoMaximo es el tamaño máximo del archivo del registro (20 MB)
+const TamanoMaximo = 20 << 20
+
+// maxErroresInforme es la cantidad de filas con errores que se informan al rechazar un archivo
+const maxErroresInforme = 20
+
+// ErrArchivoInvalido se devuelve cuando el archivo del registro no se puede importar
+var ErrArchivoInvalido = errors.New("archivo del registro del INV inválido")
+
+// columnas relaciona los nombres de columna aceptados en el CSV con cada dato
+var columnas = map[string]string{
+	"numero":        "numero",
+	"numero_inv":    "numero",
+	"numero_de_inv": "numero",
+	"nro_inv":       "numero",
+	"n_inv":         "numero",
+	"inv":           "numero",
+	"tipo":          "tipo",
+	"cuit":          "cuit",
+	"razon_social":  "razon_social",
+	"titular":       "razon_social",
+	"provincia":     "provincia",
+}
+
+// ResultadoImportacion resume la importación de un registro y la verificación de las
+// bodegas contra él
+type ResultadoImportacion struct {
+	Importados    int                     `json:"importados"`
+	Observaciones []domain.ObservacionInv `json:"observaciones"`
+}
+
+// ResultadoVerificacion resume la verificación de las bodegas contra un archivo del
+// registro que no se importó
+type ResultadoVerificacion struct {
+	Establecimientos int                     `json:"establecimientos"`
+	Observaciones    []domain.ObservacionInv `json:"observaciones"`
+}
+
+// Service importa el registro de establecimientos del INV y verifica contra él los
+// números de INV de las bodegas
+type Service struct {
+	repo *Repository
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Importar reemplaza el registro por el del archivo CSV (separado por comas o punto y
+// coma, con encabezado) y vuelve a verificar todas las bodegas. Si alguna fila es
+// inválida no se importa nada.
+func (s *Service) Importar(archivo io.Reader) (*ResultadoImportacion, error) {
+	registros, err := leerCSV(archivo, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Reemplazar(registros); err != nil {
+		return nil, err
+	}
+
+	observaciones, err := s.verificarBodegas(registros)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResultadoImportacion{Importados: len(registros), Observaciones: observaciones}, nil
+}
+
+// VerificarArchivo compara todas las bodegas con el registro de un archivo CSV sin
+// importarlo: permite revisar un registro nuevo antes de reemplazar el vigente
+func (s *Service) VerificarArchivo(archivo io.Reader) (*ResultadoVerificacion, error) {
+	registros, err := leerCSV(archivo, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	observaciones, err := s.verificarBodegas(registros)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResultadoVerificacion{Establecimientos: len(registros), Observaciones: observaciones}, nil
+}
+
+// Reverificar compara todas las bodegas con el registro importado
+func (s *Service) Reverificar() ([]domain.ObservacionInv, error) {
+	registros, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.verificarBodegas(registros)
+}
+
+// Verificar compara los números de INV de una bodega con el registro. Mientras no se haya
+// importado ningún registro no hay nada contra qué comparar y no se observa nada.
+func (s *Service) Verificar(cuit int64, inv int, viñedosInv int) ([]domain.ObservacionInv, error) {
+	hay, err := s.repo.HayRegistro()
+	if err != nil || !hay {
+		return nil, err
+	}
+
+	var observaciones []domain.ObservacionInv
+	for _, numero := range numerosDe(inv, viñedosInv) {
+		registro, err := s.repo.FindByNumero(numero.valor, numero.tipo)
+		if err != nil {
+			return nil, err
+		}
+		if observacion := observar(numero, registro, cuit); observacion != nil {
+			observaciones = append(observaciones, *observacion)
+		}
+	}
+
+	return observaciones, nil
+}
+
+func (s *Service) verificarBodegas(registros []domain.RegistroInv) ([]domain.ObservacionInv, error) {
+	observaciones := []domain.ObservacionInv{}
+	if len(registros) == 0 {
+		return observaciones, nil
+	}
+
+	indice := make(map[string]*domain.RegistroInv, len(registros))
+	for i := range registros {
+		indice[claveRegistro(registros[i].Numero, registros[i].Tipo)] = &registros[i]
+	}
+
+	bodegas, err := s.repo.FindBodegas()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bod := range bodegas {
+		for _, numero := range numerosDe(bod.Inv, bod.ViñedosInv) {
+			observacion := observar(numero, indice[claveRegistro(numero.valor, numero.tipo)], bod.Cuit)
+			if observacion == nil {
+				continue
+			}
+			observacion.IdBodega = bod.IdBodega
+			observacion.Bodega = bod.Nombre
+			observaciones = append(observaciones, *observacion)
+		}
+	}
+
+	return observaciones, nil
+}
+
+// numeroInv es un número de INV de la bodega junto con el campo y tipo de establecimiento
+type numeroInv struct {
+	campo string
+	tipo  string
+	valor int
+}
+
+// numerosDe devuelve los números de INV cargados (los que valen 0 no se informaron)
+func numerosDe(inv int, viñedosInv int) []numeroInv {
+	var numeros []numeroInv
+	if inv > 0 {
+		numeros = append(numeros, numeroInv{campo: "inv", tipo: domain.TipoInvBodega, valor: inv})
+	}
+	if viñedosInv > 0 {
+		numeros = append(numeros, numeroInv{campo: "viñedos_inv", tipo: domain.TipoInvVinedo, valor: viñedosInv})
+	}
+	return numeros
+}
+
+// observar compara un número de INV con su entrada del registro (nil si no figura)
+func observar(numero numeroInv, registro *domain.RegistroInv, cuit int64) *domain.ObservacionInv {
+	observacion := &domain.ObservacionInv{Campo: numero.campo, Numero: numero.valor}
+
+	switch {
+	case registro == nil:
+		observacion.Gravedad = domain.ObservacionAdvertencia
+		observacion.Motivo = fmt.Sprintf("el número de INV %d no figura en el registro de %s", numero.valor, plural(numero.tipo))
+	case registro.Cuit != cuit:
+		observacion.Gravedad = domain.ObservacionError
+		observacion.Motivo = fmt.Sprintf("el número de INV %d está inscripto a nombre de %s (CUIT %s)",
+			numero.valor, registro.RazonSocial, domain.FormatearCuit(registro.Cuit))
+	default:
+		return nil
+	}
+
+	return observacion
+}
+
+func plural(tipo string) string {
+	if tipo == domain.TipoInvVinedo {
+		return "viñedos"
+	}
+	return "bodegas"
+}
+
+func claveRegistro(numero int, tipo string) string {
+	return tipo + ":" + strconv.Itoa(numero)
+}
+
+// leerCSV interpreta el archivo del registro. Las columnas se identifican por el
+// encabezado; número y CUIT son obligatorios y el tipo, si falta, es bodega.
+func leerCSV(archivo io.Reader, fechaImportacion string) ([]domain.RegistroInv, error) {
+	lector := bufio.NewReader(io.LimitReader(archivo, TamanoMaximo))
+
+	// Excel en español guarda los CSV separados por punto y coma
+	primera, err := lector.Peek(4096)
+	if err != nil && err != io.EOF && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	if len(primera) == 0 {
+		return nil, fmt.Errorf("%w: el archivo está vacío", ErrArchivoInvalido)
+	}
+	if i := bytes.IndexByte(primera, '\n'); i >= 0 {
+		primera = primera[:i]
+	}
+
+	csvLector := csv.NewReader(lector)
+	if bytes.Count(primera, []byte(";")) > bytes.Count(primera, []byte(",")) {
+		csvLector.Comma = ';'
+	}
+	csvLector.FieldsPerRecord = -1
+	csvLector.TrimLeadingSpace = true
+
+	encabezado, err := csvLector.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: no se pudo leer el encabezado", ErrArchivoInvalido)
+	}
+
+	posiciones := make(map[string]int)
+	for i, titulo := range encabezado {
+		titulo = strings.TrimPrefix(titulo, "\uFEFF")
+		titulo = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(titulo)), " ", "_")
+		titulo = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "º", "", "°", "", ".", "").Replace(titulo)
+		if dato, ok := columnas[titulo]; ok {
+			if _, repetida := posiciones[dato]; !repetida {
+				posiciones[dato] = i
+			}
+		}
+	}
+	for _, obligatoria := range []string{"numero", "cuit"} {
+		if _, ok := posiciones[obligatoria]; !ok {
+			return nil, fmt.Errorf("%w: falta la columna %s", ErrArchivoInvalido, obligatoria)
+		}
+	}
+
+	var registros []domain.RegistroInv
+	var errores []string
+	vistos := make(map[string]int)
+
+	for {
+		fila, err := csvLector.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errores = append(errores, err.Error())
+			break
+		}
+		linea, _ := csvLector.FieldPos(0)
+		if filaVacia(fila) {
+			continue
+		}
+
+		registro, motivo := leerFila(fila, posiciones)
+		if motivo == "" {
+			clave := claveRegistro(registro.Numero, registro.Tipo)
+			if anterior, repetido := vistos[clave]; repetido {
+				motivo = fmt.Sprintf("el número %d ya figura en la línea %d", registro.Numero, anterior)
+			} else {
+				vistos[clave] = linea
+			}
+		}
+		if motivo != "" {
+			errores = append(errores, fmt.Sprintf("línea %d: %s", linea, motivo))
+			if len(errores) >= maxErroresInforme {
+				break
+			}
+			continue
+		}
+
+		registro.FechaImportacion = fechaImportacion
+		registros = append(registros, registro)
+	}
+
+	if len(errores) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrArchivoInvalido, strings.Join(errores, "; "))
+	}
+	if len(registros) == 0 {
+		return nil, fmt.Errorf("%w: no tiene establecimientos", ErrArchivoInvalido)
+	}
+
+	return registros, nil
+}
+
+// leerFila convierte una fila del CSV en un establecimiento, o devuelve el motivo por el
+// que es inválida
+func leerFila(fila []string, posiciones map[string]int) (domain.RegistroInv, string) {
+	valor := func(dato string) string {
+		i, ok := posiciones[dato]
+		if !ok || i >= len(fila) {
+			return ""
+		}
+		return strings.TrimSpace(fila[i])
+	}
+
+	var registro domain.RegistroInv
+
+	numero, err := strconv.Atoi(valor("numero"))
+	if err != nil || numero <= 0 {
+		return registro, fmt.Sprintf("número de INV inválido: %q", valor("numero"))
+	}
+	registro.Numero = numero
+
+	cuit, ok := domain.ParseCuit(valor("cuit"))
+	if !ok || !domain.CuitValido(cuit) {
+		return registro, fmt.Sprintf("CUIT inválido: %q", valor("cuit"))
+	}
+	registro.Cuit = cuit
+
+	switch strings.ToLower(valor("tipo")) {
+	case "", "bodega":
+		registro.Tipo = domain.TipoInvBodega
+	case "viñedo", "vinedo":
+		registro.Tipo = domain.TipoInvVinedo
+	default:
+		return registro, fmt.Sprintf("tipo de establecimiento inválido: %q (bodega o viñedo)", valor("tipo"))
+	}
+
+	registro.RazonSocial = valor("razon_social")
+	if provincia := valor("provincia"); provincia != "" {
+		registro.Provincia = &provincia
+	}
+
+	return registro, ""
+}
+
+func filaVacia(fila []string) bool {
+	for _, valor := range fila {
+		if strings.TrimSpace(valor) != "" {
+			return false
+		}
+	}
+	return true
+}
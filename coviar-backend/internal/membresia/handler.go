@@ -0,0 +1,275 @@
+// RUTA: coviar-backend/internal/membresia/handler.go
+package membresia
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/auth"
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Handler maneja las peticiones HTTP de miembros e invitaciones de bodegas
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// EsRutaMembresia indica si la ruta corresponde a los miembros o invitaciones de una
+// bodega (/api/bodegas/{id}/miembros... o /api/bodegas/{id}/invitaciones...)
+func EsRutaMembresia(path string) bool {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api/bodegas/"), "/"), "/")
+	return len(parts) >= 2 && (parts[1] == "miembros" || parts[1] == "invitaciones")
+}
+
+// Route despacha las rutas de /api/bodegas/{id}/miembros y /api/bodegas/{id}/invitaciones
+func (h *Handler) Route(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	if !ok || claims == nil {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/bodegas/"), "/")
+	parts := strings.Split(path, "/")
+
+	idBodega, err := strconv.Atoi(parts[0])
+	if err != nil {
+		sendError(w, "ID de bodega inválido", http.StatusBadRequest)
+		return
+	}
+
+	var id int
+	if len(parts) == 3 {
+		if id, err = strconv.Atoi(parts[2]); err != nil {
+			sendError(w, "ID inválido", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Los miembros los puede ver cualquier miembro; un miembro se puede quitar a sí
+	// mismo. Todo lo demás es del propietario.
+	minimo := domain.RolBodegaPropietario
+	if parts[1] == "miembros" && (r.Method == http.MethodGet || (r.Method == http.MethodDelete && id == claims.IdUsuario)) {
+		minimo = domain.RolBodegaLector
+	}
+	if err := h.service.Verificar(claims, idBodega, minimo); err != nil {
+		sendServiceError(w, err, "Error al verificar permisos", http.StatusInternalServerError)
+		return
+	}
+
+	switch {
+	case len(parts) == 2 && parts[1] == "miembros" && r.Method == http.MethodGet:
+		h.ListMiembros(w, r, idBodega)
+	case len(parts) == 3 && parts[1] == "miembros" && r.Method == http.MethodPut:
+		h.UpdateMiembro(w, r, idBodega, id)
+	case len(parts) == 3 && parts[1] == "miembros" && r.Method == http.MethodDelete:
+		h.DeleteMiembro(w, r, idBodega, id)
+	case len(parts) == 2 && parts[1] == "invitaciones" && r.Method == http.MethodGet:
+		h.ListInvitaciones(w, r, idBodega)
+	case len(parts) == 2 && parts[1] == "invitaciones" && r.Method == http.MethodPost:
+		h.CreateInvitacion(w, r, idBodega, claims)
+	case len(parts) == 3 && parts[1] == "invitaciones" && r.Method == http.MethodDelete:
+		h.DeleteInvitacion(w, r, idBodega, id)
+	default:
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+// ListMiembros maneja GET /api/bodegas/{id}/miembros
+func (h *Handler) ListMiembros(w http.ResponseWriter, r *http.Request, idBodega int) {
+	miembros, err := h.service.GetMiembros(idBodega)
+	if err != nil {
+		log.Printf("Error al obtener miembros de la bodega: %v", err)
+		sendError(w, "Error al obtener miembros", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, miembros)
+}
+
+// UpdateMiembro maneja PUT /api/bodegas/{id}/miembros/{idUsuario} - Cambiar el rol
+func (h *Handler) UpdateMiembro(w http.ResponseWriter, r *http.Request, idBodega int, idUsuario int) {
+	var body struct {
+		Rol string `json:"rol"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	miembro, err := h.service.CambiarRol(idBodega, idUsuario, body.Rol)
+	if err != nil {
+		log.Printf("Error al cambiar rol del miembro: %v", err)
+		sendServiceError(w, err, "Error al cambiar rol del miembro", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, miembro)
+}
+
+// DeleteMiembro maneja DELETE /api/bodegas/{id}/miembros/{idUsuario}
+func (h *Handler) DeleteMiembro(w http.ResponseWriter, r *http.Request, idBodega int, idUsuario int) {
+	if err := h.service.Quitar(idBodega, idUsuario); err != nil {
+		log.Printf("Error al quitar miembro de la bodega: %v", err)
+		sendServiceError(w, err, "Error al quitar miembro", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, map[string]int{"idUsuario": idUsuario})
+}
+
+// ListInvitaciones maneja GET /api/bodegas/{id}/invitaciones - Invitaciones sin aceptar
+func (h *Handler) ListInvitaciones(w http.ResponseWriter, r *http.Request, idBodega int) {
+	invitaciones, err := h.service.GetInvitaciones(idBodega)
+	if err != nil {
+		log.Printf("Error al obtener invitaciones: %v", err)
+		sendError(w, "Error al obtener invitaciones", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, invitaciones)
+}
+
+// CreateInvitacion maneja POST /api/bodegas/{id}/invitaciones {email, rol}
+func (h *Handler) CreateInvitacion(w http.ResponseWriter, r *http.Request, idBodega int, claims *auth.Claims) {
+	var body struct {
+		Email string `json:"email"`
+		Rol   string `json:"rol"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	invitacion, err := h.service.Invitar(idBodega, body.Email, body.Rol, claims.IdUsuario)
+	if err != nil {
+		log.Printf("Error al crear invitación: %v", err)
+		sendServiceError(w, err, "Error al crear invitación", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	sendSuccess(w, invitacion)
+}
+
+// DeleteInvitacion maneja DELETE /api/bodegas/{id}/invitaciones/{idInvitacion}
+func (h *Handler) DeleteInvitacion(w http.ResponseWriter, r *http.Request, idBodega int, idInvitacion int) {
+	if err := h.service.Revocar(idBodega, idInvitacion); err != nil {
+		log.Printf("Error al revocar invitación: %v", err)
+		sendServiceError(w, err, "Error al revocar invitación", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, map[string]int{"idInvitacion": idInvitacion})
+}
+
+// AceptarInvitacion maneja POST /api/invitaciones/aceptar {token}
+func (h *Handler) AceptarInvitacion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Token == "" {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	claims, _ := r.Context().Value("claims").(*auth.Claims)
+	miembro, err := h.service.Aceptar(body.Token, claims)
+	if err != nil {
+		log.Printf("Error al aceptar invitación: %v", err)
+		sendServiceError(w, err, "Error al aceptar invitación", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, miembro)
+}
+
+// ListMembresias maneja GET /api/membresias - Bodegas del usuario y su rol en cada una
+func (h *Handler) ListMembresias(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	if !ok || claims == nil {
+		sendError(w, "No autenticado", http.StatusUnauthorized)
+		return
+	}
+
+	membresias, err := h.service.GetMembresias(claims.IdUsuario)
+	if err != nil {
+		log.Printf("Error al obtener membresías: %v", err)
+		sendError(w, "Error al obtener membresías", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, membresias)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// sendServiceError traduce los errores de negocio del servicio al código HTTP adecuado
+func sendServiceError(w http.ResponseWriter, err error, fallback string, fallbackStatus int) {
+	switch {
+	case errors.Is(err, ErrNoAutenticado):
+		sendError(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, ErrSinAcceso), errors.Is(err, ErrInvitacionOtroEmail), errors.Is(err, ErrUsuarioNoBodega):
+		sendError(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrBodegaNoEncontrada), errors.Is(err, ErrMiembroNoEncontrado), errors.Is(err, ErrInvitacionNoEncontrada):
+		sendError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrYaEsMiembro), errors.Is(err, ErrUltimoPropietario), errors.Is(err, ErrInvitacionAceptada):
+		sendError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrInvitacionVencida):
+		sendError(w, err.Error(), http.StatusGone)
+	case errors.Is(err, ErrRolBodegaInvalido), errors.Is(err, ErrEmailInvalido):
+		sendError(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		sendError(w, fallback, fallbackStatus)
+	}
+}
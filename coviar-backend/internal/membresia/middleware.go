@@ -0,0 +1,137 @@
+// RUTA: coviar-backend/internal/membresia/middleware.go
+package membresia
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/auth"
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Resolver obtiene de la petición el ID de la bodega sobre la que se actúa
+type Resolver func(r *http.Request) (int, error)
+
+// DeConsulta toma el ID del parámetro de consulta indicado (por ejemplo ?idBodega=)
+func DeConsulta(parametro string) Resolver {
+	return func(r *http.Request) (int, error) {
+		return idPositivo(r.URL.Query().Get(parametro))
+	}
+}
+
+// DeRuta toma el ID del primer segmento de la ruta después del prefijo
+// (por ejemplo /api/bodegas/{id}/...)
+func DeRuta(prefijo string) Resolver {
+	return func(r *http.Request) (int, error) {
+		path := strings.Trim(strings.TrimPrefix(r.URL.Path, prefijo), "/")
+		return idPositivo(strings.Split(path, "/")[0])
+	}
+}
+
+// DelCuerpo toma el ID de un campo del cuerpo JSON, que se deja intacto para el handler
+func DelCuerpo(campo string) Resolver {
+	return func(r *http.Request) (int, error) {
+		contenido, err := io.ReadAll(r.Body)
+		if err != nil {
+			return 0, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(contenido))
+
+		var cuerpo map[string]json.RawMessage
+		if err := json.Unmarshal(contenido, &cuerpo); err != nil {
+			return 0, ErrBodegaNoIndicada
+		}
+		var id int
+		if err := json.Unmarshal(cuerpo[campo], &id); err != nil || id <= 0 {
+			return 0, ErrBodegaNoIndicada
+		}
+		return id, nil
+	}
+}
+
+// Mediante obtiene el ID de otro recurso (evaluación, ítem de plan, ...) y lo traduce a
+// la bodega a la que pertenece. buscar debe devolver ErrRecursoNoEncontrado si no existe.
+func Mediante(resolver Resolver, buscar func(id int) (int, error)) Resolver {
+	return func(r *http.Request) (int, error) {
+		id, err := resolver(r)
+		if err != nil {
+			return 0, err
+		}
+		return buscar(id)
+	}
+}
+
+// RequireMiembro exige que los usuarios con rol bodega sean miembros de la bodega: para
+// consultar (GET) alcanza con lector; para modificar hace falta al menos editor.
+func (s *Service) RequireMiembro(resolver Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			minimo := domain.RolBodegaEditor
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				minimo = domain.RolBodegaLector
+			}
+			s.RequireRolBodega(minimo, resolver)(next).ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireRolBodega exige que los usuarios con rol bodega tengan al menos el rol mínimo
+// en la bodega. Administradores y auditores pasan sin resolver la bodega; cualquier otro
+// rol, o una petición sin sesión, se rechaza.
+func (s *Service) RequireRolBodega(minimo string, resolver Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, _ := r.Context().Value("claims").(*auth.Claims)
+			if claims != nil && (claims.Rol == "admin" || claims.Rol == "auditor") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+
+			if claims == nil {
+				sendServiceError(w, ErrNoAutenticado, "", 0)
+				return
+			}
+			if claims.Rol != "bodega" {
+				sendServiceError(w, ErrSinAcceso, "", 0)
+				return
+			}
+
+			idBodega, err := resolver(r)
+			if err != nil {
+				switch {
+				case errors.Is(err, ErrBodegaNoIndicada):
+					sendError(w, err.Error(), http.StatusBadRequest)
+				case errors.Is(err, ErrRecursoNoEncontrado):
+					sendError(w, err.Error(), http.StatusNotFound)
+				default:
+					log.Printf("Error al resolver la bodega de la petición: %v", err)
+					sendError(w, "Error al verificar permisos", http.StatusInternalServerError)
+				}
+				return
+			}
+
+			if err := s.Verificar(claims, idBodega, minimo); err != nil {
+				sendServiceError(w, err, "Error al verificar permisos", http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func idPositivo(valor string) (int, error) {
+	id, err := strconv.Atoi(valor)
+	if err != nil || id <= 0 {
+		return 0, ErrBodegaNoIndicada
+	}
+	return id, nil
+}
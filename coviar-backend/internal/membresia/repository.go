@@ -0,0 +1,335 @@
+// RUTA: coviar-backend/internal/membresia/repository.go
+package membresia
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// ordenAscendente ordena los resultados de menor a mayor
+var ordenAscendente = postgrest.OrderOpts{Ascending: true}
+
+// Repository maneja el acceso a datos de MiembroBodega e InvitacionBodega
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindMiembro obtiene la membresía de un usuario en una bodega, o nil si no es miembro
+func (r *Repository) FindMiembro(idUsuario int, idBodega int) (*domain.MiembroBodega, error) {
+	data, _, err := r.db.From("miembro_bodega").
+		Select("*", "", false).
+		Eq("idUsuario", fmt.Sprintf("%d", idUsuario)).
+		Eq("idBodega", fmt.Sprintf("%d", idBodega)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var miembros []domain.MiembroBodega
+	if err := json.Unmarshal(data, &miembros); err != nil {
+		return nil, err
+	}
+
+	if len(miembros) == 0 {
+		return nil, nil
+	}
+
+	return &miembros[0], nil
+}
+
+// FindByBodega obtiene los miembros de una bodega con sus datos de usuario
+func (r *Repository) FindByBodega(idBodega int) ([]domain.MiembroBodega, error) {
+	data, _, err := r.db.From("miembro_bodega").
+		Select("*,usuario(nombre,apellido,email)", "", false).
+		Eq("idBodega", fmt.Sprintf("%d", idBodega)).
+		Order("fecha_alta", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var miembros []domain.MiembroBodega
+	if err := json.Unmarshal(data, &miembros); err != nil {
+		return nil, err
+	}
+
+	return miembros, nil
+}
+
+// FindByUsuario obtiene las membresías de un usuario
+func (r *Repository) FindByUsuario(idUsuario int) ([]domain.MiembroBodega, error) {
+	data, _, err := r.db.From("miembro_bodega").
+		Select("*", "", false).
+		Eq("idUsuario", fmt.Sprintf("%d", idUsuario)).
+		Order("fecha_alta", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var miembros []domain.MiembroBodega
+	if err := json.Unmarshal(data, &miembros); err != nil {
+		return nil, err
+	}
+
+	return miembros, nil
+}
+
+// CreateMiembro agrega un usuario a una bodega
+func (r *Repository) CreateMiembro(miembro *domain.MiembroBodega) error {
+	miembroMap := map[string]interface{}{
+		"idUsuario":  miembro.IdUsuario,
+		"idBodega":   miembro.IdBodega,
+		"rol":        miembro.Rol,
+		"fecha_alta": time.Now().Format(time.RFC3339),
+	}
+
+	data, _, err := r.db.From("miembro_bodega").
+		Insert(miembroMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []domain.MiembroBodega
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*miembro = result[0]
+	}
+
+	return nil
+}
+
+// UpdateRolMiembro cambia el rol de un miembro
+func (r *Repository) UpdateRolMiembro(idMiembro int, rol string) error {
+	_, _, err := r.db.From("miembro_bodega").
+		Update(map[string]interface{}{"rol": rol}, "", "").
+		Eq("idMiembro", fmt.Sprintf("%d", idMiembro)).
+		Execute()
+
+	return err
+}
+
+// DeleteMiembro quita a un usuario de una bodega
+func (r *Repository) DeleteMiembro(idMiembro int) error {
+	_, _, err := r.db.From("miembro_bodega").
+		Delete("minimal", "").
+		Eq("idMiembro", fmt.Sprintf("%d", idMiembro)).
+		Execute()
+
+	return err
+}
+
+// CreateInvitacion guarda una invitación
+func (r *Repository) CreateInvitacion(invitacion *domain.InvitacionBodega) error {
+	invitacionMap := map[string]interface{}{
+		"idBodega":           invitacion.IdBodega,
+		"email":              invitacion.Email,
+		"rol":                invitacion.Rol,
+		"token_hash":         invitacion.TokenHash,
+		"idUsuarioInvitante": invitacion.IdUsuarioInvitante,
+		"fecha_creacion":     invitacion.FechaCreacion,
+		"fecha_expiracion":   invitacion.FechaExpiracion,
+	}
+
+	data, _, err := r.db.From("invitacion_bodega").
+		Insert(invitacionMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []domain.InvitacionBodega
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*invitacion = result[0]
+	}
+
+	return nil
+}
+
+// FindInvitacionByToken obtiene una invitación por el hash de su token
+func (r *Repository) FindInvitacionByToken(tokenHash string) (*domain.InvitacionBodega, error) {
+	data, _, err := r.db.From("invitacion_bodega").
+		Select("*", "", false).
+		Eq("token_hash", tokenHash).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var invitaciones []domain.InvitacionBodega
+	if err := json.Unmarshal(data, &invitaciones); err != nil {
+		return nil, err
+	}
+
+	if len(invitaciones) == 0 {
+		return nil, ErrInvitacionNoEncontrada
+	}
+
+	return &invitaciones[0], nil
+}
+
+// FindInvitacionByID obtiene una invitación por ID
+func (r *Repository) FindInvitacionByID(id int) (*domain.InvitacionBodega, error) {
+	data, _, err := r.db.From("invitacion_bodega").
+		Select("*", "", false).
+		Eq("idInvitacion", fmt.Sprintf("%d", id)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var invitaciones []domain.InvitacionBodega
+	if err := json.Unmarshal(data, &invitaciones); err != nil {
+		return nil, err
+	}
+
+	if len(invitaciones) == 0 {
+		return nil, ErrInvitacionNoEncontrada
+	}
+
+	return &invitaciones[0], nil
+}
+
+// FindInvitacionesPendientes obtiene las invitaciones de una bodega que todavía no se
+// aceptaron (incluidas las vencidas, para que el propietario las vea)
+func (r *Repository) FindInvitacionesPendientes(idBodega int) ([]domain.InvitacionBodega, error) {
+	data, _, err := r.db.From("invitacion_bodega").
+		Select("*", "", false).
+		Eq("idBodega", fmt.Sprintf("%d", idBodega)).
+		Is("fecha_aceptacion", "null").
+		Order("fecha_creacion", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var invitaciones []domain.InvitacionBodega
+	if err := json.Unmarshal(data, &invitaciones); err != nil {
+		return nil, err
+	}
+
+	return invitaciones, nil
+}
+
+// MarcarAceptada registra la aceptación de una invitación, solo si no se había aceptado.
+// Devuelve false si otra petición la aceptó entretanto.
+func (r *Repository) MarcarAceptada(idInvitacion int) (bool, error) {
+	data, _, err := r.db.From("invitacion_bodega").
+		Update(map[string]interface{}{"fecha_aceptacion": time.Now().Format(time.RFC3339)}, "", "").
+		Eq("idInvitacion", fmt.Sprintf("%d", idInvitacion)).
+		Is("fecha_aceptacion", "null").
+		Execute()
+
+	if err != nil {
+		return false, err
+	}
+
+	var result []domain.InvitacionBodega
+	if err := json.Unmarshal(data, &result); err != nil {
+		return false, err
+	}
+
+	return len(result) > 0, nil
+}
+
+// DeleteInvitacion revoca una invitación
+func (r *Repository) DeleteInvitacion(idInvitacion int) error {
+	_, _, err := r.db.From("invitacion_bodega").
+		Delete("minimal", "").
+		Eq("idInvitacion", fmt.Sprintf("%d", idInvitacion)).
+		Execute()
+
+	return err
+}
+
+// DeleteInvitacionesPendientes revoca las invitaciones sin aceptar de un email a una bodega
+func (r *Repository) DeleteInvitacionesPendientes(idBodega int, email string) error {
+	_, _, err := r.db.From("invitacion_bodega").
+		Delete("minimal", "").
+		Eq("idBodega", fmt.Sprintf("%d", idBodega)).
+		Eq("email", strings.ToLower(email)).
+		Is("fecha_aceptacion", "null").
+		Execute()
+
+	return err
+}
+
+// FindNombreBodega obtiene el nombre de una bodega, para el correo de invitación
+func (r *Repository) FindNombreBodega(idBodega int) (string, error) {
+	data, _, err := r.db.From("bodega").
+		Select("nombre", "", false).
+		Eq("idBodega", fmt.Sprintf("%d", idBodega)).
+		Execute()
+
+	if err != nil {
+		return "", err
+	}
+
+	var bodegas []struct {
+		Nombre string `json:"nombre"`
+	}
+	if err := json.Unmarshal(data, &bodegas); err != nil {
+		return "", err
+	}
+
+	if len(bodegas) == 0 {
+		return "", ErrBodegaNoEncontrada
+	}
+
+	return bodegas[0].Nombre, nil
+}
+
+// ExisteMiembroConEmail indica si la bodega ya tiene un miembro con ese email
+func (r *Repository) ExisteMiembroConEmail(idBodega int, email string) (bool, error) {
+	data, _, err := r.db.From("miembro_bodega").
+		Select(`"idMiembro",usuario!inner(email)`, "", false).
+		Eq("idBodega", fmt.Sprintf("%d", idBodega)).
+		Ilike("usuario.email", escaparLike(email)).
+		Execute()
+
+	if err != nil {
+		return false, err
+	}
+
+	var miembros []struct {
+		IdMiembro int `json:"idMiembro"`
+	}
+	if err := json.Unmarshal(data, &miembros); err != nil {
+		return false, err
+	}
+
+	return len(miembros) > 0, nil
+}
+
+// escaparLike escapa los comodines de LIKE para comparar el texto tal cual, sin
+// distinguir mayúsculas
+func escaparLike(texto string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(texto)
+}
@@ -0,0 +1,341 @@
+// RUTA: coviar-backend/internal/membresia/service.go
+package membresia
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/auth"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/platform/email"
+)
+
+// Errores de negocio que el handler traduce a códigos HTTP
+var (
+	ErrNoAutenticado          = errors.New("no autenticado")
+	ErrSinAcceso              = errors.New("no tiene acceso a esta bodega")
+	ErrBodegaNoIndicada       = errors.New("falta el ID de la bodega o del recurso, o es inválido")
+	ErrRecursoNoEncontrado    = errors.New("recurso no encontrado")
+	ErrBodegaNoEncontrada     = errors.New("bodega no encontrada")
+	ErrRolBodegaInvalido      = errors.New("rol inválido: debe ser propietario, editor o lector")
+	ErrMiembroNoEncontrado    = errors.New("el usuario no es miembro de la bodega")
+	ErrYaEsMiembro            = errors.New("el usuario ya es miembro de la bodega")
+	ErrUltimoPropietario      = errors.New("la bodega debe conservar al menos un propietario")
+	ErrEmailInvalido          = errors.New("email inválido")
+	ErrInvitacionNoEncontrada = errors.New("invitación no encontrada")
+	ErrInvitacionVencida      = errors.New("la invitación venció")
+	ErrInvitacionAceptada     = errors.New("la invitación ya fue aceptada")
+	ErrInvitacionOtroEmail    = errors.New("la invitación fue enviada a otro email")
+	ErrUsuarioNoBodega        = errors.New("solo los usuarios con rol bodega pueden sumarse a una bodega")
+)
+
+// DiasValidezInvitacion es el tiempo que tiene el invitado para aceptar la invitación
+const DiasValidezInvitacion = 7
+
+// Service contiene la lógica de las membresías de usuarios en bodegas
+type Service struct {
+	repo        *Repository
+	mailer      email.Mailer
+	frontendURL string
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, mailer email.Mailer, frontendURL string) *Service {
+	return &Service{repo: repo, mailer: mailer, frontendURL: frontendURL}
+}
+
+// Verificar comprueba que el usuario pueda actuar sobre la bodega con al menos el rol
+// mínimo. Solo se aplica al rol de sistema bodega: administradores y auditores no son
+// miembros de bodegas y sus permisos los siguen controlando los roles del sistema.
+func (s *Service) Verificar(claims *auth.Claims, idBodega int, minimo string) error {
+	if claims == nil {
+		return ErrNoAutenticado
+	}
+	switch claims.Rol {
+	case "admin", "auditor":
+		return nil
+	case "bodega":
+	default:
+		return ErrSinAcceso
+	}
+
+	miembro, err := s.repo.FindMiembro(claims.IdUsuario, idBodega)
+	if err != nil {
+		return err
+	}
+	if miembro == nil || !domain.RolBodegaAlcanza(miembro.Rol, minimo) {
+		return ErrSinAcceso
+	}
+
+	return nil
+}
+
+// GetMiembros obtiene los miembros de una bodega
+func (s *Service) GetMiembros(idBodega int) ([]domain.MiembroBodega, error) {
+	return s.repo.FindByBodega(idBodega)
+}
+
+// GetMembresias obtiene las bodegas de las que es miembro un usuario, con su rol en cada una
+func (s *Service) GetMembresias(idUsuario int) ([]domain.MiembroBodega, error) {
+	return s.repo.FindByUsuario(idUsuario)
+}
+
+// Agregar suma un usuario a una bodega con el rol indicado
+func (s *Service) Agregar(idUsuario int, idBodega int, rol string) (*domain.MiembroBodega, error) {
+	if !domain.RolBodegaValido(rol) {
+		return nil, ErrRolBodegaInvalido
+	}
+
+	existente, err := s.repo.FindMiembro(idUsuario, idBodega)
+	if err != nil {
+		return nil, err
+	}
+	if existente != nil {
+		return nil, ErrYaEsMiembro
+	}
+
+	miembro := &domain.MiembroBodega{IdUsuario: idUsuario, IdBodega: idBodega, Rol: rol}
+	if err := s.repo.CreateMiembro(miembro); err != nil {
+		return nil, err
+	}
+
+	return miembro, nil
+}
+
+// CambiarRol cambia el rol de un miembro. La bodega no puede quedarse sin propietario.
+func (s *Service) CambiarRol(idBodega int, idUsuario int, rol string) (*domain.MiembroBodega, error) {
+	if !domain.RolBodegaValido(rol) {
+		return nil, ErrRolBodegaInvalido
+	}
+
+	miembro, err := s.miembro(idBodega, idUsuario)
+	if err != nil {
+		return nil, err
+	}
+
+	if miembro.Rol == domain.RolBodegaPropietario && rol != domain.RolBodegaPropietario {
+		if err := s.verificarOtroPropietario(idBodega); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateRolMiembro(miembro.IdMiembro, rol); err != nil {
+		return nil, err
+	}
+
+	miembro.Rol = rol
+	return miembro, nil
+}
+
+// Quitar quita a un usuario de una bodega. La bodega no puede quedarse sin propietario.
+func (s *Service) Quitar(idBodega int, idUsuario int) error {
+	miembro, err := s.miembro(idBodega, idUsuario)
+	if err != nil {
+		return err
+	}
+
+	if miembro.Rol == domain.RolBodegaPropietario {
+		if err := s.verificarOtroPropietario(idBodega); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.DeleteMiembro(miembro.IdMiembro)
+}
+
+// Invitar invita a una persona por email a sumarse a la bodega. Si ya tenía una
+// invitación pendiente se reemplaza, así solo vale el último enlace enviado.
+func (s *Service) Invitar(idBodega int, destinatario string, rol string, idInvitante int) (*domain.InvitacionBodega, error) {
+	direccion, err := mail.ParseAddress(strings.TrimSpace(destinatario))
+	if err != nil {
+		return nil, ErrEmailInvalido
+	}
+	destinatario = strings.ToLower(direccion.Address)
+
+	if !domain.RolBodegaValido(rol) {
+		return nil, ErrRolBodegaInvalido
+	}
+
+	nombreBodega, err := s.repo.FindNombreBodega(idBodega)
+	if err != nil {
+		return nil, err
+	}
+
+	yaEsMiembro, err := s.repo.ExisteMiembroConEmail(idBodega, destinatario)
+	if err != nil {
+		return nil, err
+	}
+	if yaEsMiembro {
+		return nil, ErrYaEsMiembro
+	}
+
+	if err := s.repo.DeleteInvitacionesPendientes(idBodega, destinatario); err != nil {
+		return nil, err
+	}
+
+	token, err := generarToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ahora := time.Now()
+	invitacion := &domain.InvitacionBodega{
+		IdBodega:           idBodega,
+		Email:              destinatario,
+		Rol:                rol,
+		TokenHash:          hashToken(token),
+		IdUsuarioInvitante: idInvitante,
+		FechaCreacion:      ahora.Format(time.RFC3339),
+		FechaExpiracion:    ahora.AddDate(0, 0, DiasValidezInvitacion).Format(time.RFC3339),
+	}
+	if err := s.repo.CreateInvitacion(invitacion); err != nil {
+		return nil, err
+	}
+
+	asunto := fmt.Sprintf("Invitación a la bodega %s", nombreBodega)
+	if err := s.mailer.Enviar(destinatario, asunto, s.cuerpoInvitacion(nombreBodega, rol, token)); err != nil {
+		// Sin el correo nadie puede usar el token: se descarta la invitación
+		if errBorrado := s.repo.DeleteInvitacion(invitacion.IdInvitacion); errBorrado != nil {
+			log.Printf("Error al descartar la invitación %d: %v", invitacion.IdInvitacion, errBorrado)
+		}
+		return nil, fmt.Errorf("no se pudo enviar la invitación: %w", err)
+	}
+
+	return invitacion, nil
+}
+
+// GetInvitaciones obtiene las invitaciones sin aceptar de una bodega
+func (s *Service) GetInvitaciones(idBodega int) ([]domain.InvitacionBodega, error) {
+	return s.repo.FindInvitacionesPendientes(idBodega)
+}
+
+// Revocar anula una invitación sin aceptar de la bodega
+func (s *Service) Revocar(idBodega int, idInvitacion int) error {
+	invitacion, err := s.repo.FindInvitacionByID(idInvitacion)
+	if err != nil {
+		return err
+	}
+	if invitacion.IdBodega != idBodega {
+		return ErrInvitacionNoEncontrada
+	}
+	if invitacion.FechaAceptacion != nil {
+		return ErrInvitacionAceptada
+	}
+
+	return s.repo.DeleteInvitacion(idInvitacion)
+}
+
+// Aceptar suma al usuario a la bodega de la invitación. El token es de un solo uso y
+// solo lo puede aceptar el usuario con el email al que se envió.
+func (s *Service) Aceptar(token string, claims *auth.Claims) (*domain.MiembroBodega, error) {
+	if claims == nil {
+		return nil, ErrNoAutenticado
+	}
+	if claims.Rol != "bodega" {
+		return nil, ErrUsuarioNoBodega
+	}
+
+	invitacion, err := s.repo.FindInvitacionByToken(hashToken(strings.TrimSpace(token)))
+	if err != nil {
+		return nil, err
+	}
+
+	if invitacion.FechaAceptacion != nil {
+		return nil, ErrInvitacionAceptada
+	}
+	expiracion, err := time.Parse(time.RFC3339, invitacion.FechaExpiracion)
+	if err != nil || time.Now().After(expiracion) {
+		return nil, ErrInvitacionVencida
+	}
+	if !strings.EqualFold(invitacion.Email, claims.Email) {
+		return nil, ErrInvitacionOtroEmail
+	}
+
+	// Primero se suma al usuario: la invitación se consume solo si eso funcionó. Si ya era
+	// miembro (por ejemplo, al aceptar dos veces a la vez) se conserva su membresía.
+	miembro, err := s.Agregar(claims.IdUsuario, invitacion.IdBodega, invitacion.Rol)
+	if errors.Is(err, ErrYaEsMiembro) {
+		miembro, err = s.miembro(invitacion.IdBodega, claims.IdUsuario)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.MarcarAceptada(invitacion.IdInvitacion); err != nil {
+		log.Printf("Error al marcar aceptada la invitación %d: %v", invitacion.IdInvitacion, err)
+	}
+
+	return miembro, nil
+}
+
+func (s *Service) miembro(idBodega int, idUsuario int) (*domain.MiembroBodega, error) {
+	miembro, err := s.repo.FindMiembro(idUsuario, idBodega)
+	if err != nil {
+		return nil, err
+	}
+	if miembro == nil {
+		return nil, ErrMiembroNoEncontrado
+	}
+	return miembro, nil
+}
+
+// verificarOtroPropietario falla si la bodega tiene un único propietario
+func (s *Service) verificarOtroPropietario(idBodega int) error {
+	miembros, err := s.repo.FindByBodega(idBodega)
+	if err != nil {
+		return err
+	}
+
+	propietarios := 0
+	for _, miembro := range miembros {
+		if miembro.Rol == domain.RolBodegaPropietario {
+			propietarios++
+		}
+	}
+	if propietarios <= 1 {
+		return ErrUltimoPropietario
+	}
+
+	return nil
+}
+
+func (s *Service) cuerpoInvitacion(nombreBodega string, rol string, token string) string {
+	url := fmt.Sprintf("%s/invitacion?token=%s", s.frontendURL, token)
+
+	return fmt.Sprintf(`
+		<!DOCTYPE html>
+		<html>
+		<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+			<div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+				<h2>Invitación a %s</h2>
+				<p>Lo invitaron a sumarse a la bodega <strong>%s</strong> como <strong>%s</strong>.</p>
+				<p>Para aceptar, inicie sesión (o regístrese con este email) y abra el siguiente enlace:</p>
+				<p style="word-break: break-all;"><a href="%s">%s</a></p>
+				<p><strong>La invitación vence en %d días.</strong></p>
+			</div>
+		</body>
+		</html>
+	`, html.EscapeString(nombreBodega), html.EscapeString(nombreBodega), rol, url, url, DiasValidezInvitacion)
+}
+
+// generarToken genera un token aleatorio de 32 bytes en hexadecimal
+func generarToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func hashToken(token string) string {
+	suma := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(suma[:])
+}
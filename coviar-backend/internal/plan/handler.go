@@ -0,0 +1,155 @@
+// RUTA: coviar-backend/internal/plan/handler.go
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/evaluacion"
+)
+
+// Handler maneja las peticiones HTTP para los planes de mejora
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetPlan maneja GET /api/planes?idBodega={id}
+func (h *Handler) GetPlan(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	idBodega, err := strconv.Atoi(r.URL.Query().Get("idBodega"))
+	if err != nil {
+		sendError(w, "ID de bodega inválido", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.service.GetByBodega(idBodega)
+	if err != nil {
+		log.Printf("Error al obtener plan de mejora: %v", err)
+		sendServiceError(w, err, "Error al obtener plan de mejora", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, plan)
+}
+
+// GenerarPlan maneja POST /api/planes - Genera (o regenera) el plan de la bodega a partir de una evaluación
+func (h *Handler) GenerarPlan(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var body struct {
+		IdEvaluacion int `json:"idEvaluacion"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.IdEvaluacion <= 0 {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.service.Generar(body.IdEvaluacion)
+	if err != nil {
+		log.Printf("Error al generar plan de mejora: %v", err)
+		sendServiceError(w, err, "Error al generar plan de mejora", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	sendSuccess(w, plan)
+}
+
+// RouteItem despacha las rutas de /api/planes/items/{id}
+func (h *Handler) RouteItem(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/planes/items/"), "/"))
+	if err != nil {
+		sendError(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		h.UpdateItem(w, r, id)
+	case http.MethodDelete:
+		h.DeleteItem(w, r, id)
+	default:
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+	}
+}
+
+// UpdateItem maneja PUT /api/planes/items/{id}
+func (h *Handler) UpdateItem(w http.ResponseWriter, r *http.Request, id int) {
+	var cambios CambiosItem
+	if err := json.NewDecoder(r.Body).Decode(&cambios); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.service.ActualizarItem(id, cambios)
+	if err != nil {
+		log.Printf("Error al actualizar ítem del plan: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, item)
+}
+
+// DeleteItem maneja DELETE /api/planes/items/{id}
+func (h *Handler) DeleteItem(w http.ResponseWriter, r *http.Request, id int) {
+	if err := h.service.EliminarItem(id); err != nil {
+		log.Printf("Error al eliminar ítem del plan: %v", err)
+		sendServiceError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendSuccess(w, map[string]int{"idItem": id})
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// sendServiceError traduce los errores de negocio del servicio al código HTTP adecuado
+func sendServiceError(w http.ResponseWriter, err error, fallback string, fallbackStatus int) {
+	switch {
+	case errors.Is(err, ErrPlanNoEncontrado), errors.Is(err, ErrItemNoEncontrado),
+		errors.Is(err, evaluacion.ErrEvaluacionNoEncontrada):
+		sendError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrEvaluacionNoFinalizada):
+		sendError(w, err.Error(), http.StatusConflict)
+	default:
+		sendError(w, fallback, fallbackStatus)
+	}
+}
@@ -0,0 +1,248 @@
+// RUTA: coviar-backend/internal/plan/repository.go
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// ordenAscendente ordena los resultados de menor a mayor
+var ordenAscendente = postgrest.OrderOpts{Ascending: true}
+
+// Repository maneja el acceso a datos de los planes de mejora
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindByBodega obtiene el plan de mejora de una bodega
+func (r *Repository) FindByBodega(idBodega int) (*domain.PlanMejora, error) {
+	data, _, err := r.db.From("plan_mejora").
+		Select("*", "", false).
+		Eq("idBodega", fmt.Sprintf("%d", idBodega)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var planes []domain.PlanMejora
+	if err := json.Unmarshal(data, &planes); err != nil {
+		return nil, err
+	}
+
+	if len(planes) == 0 {
+		return nil, ErrPlanNoEncontrado
+	}
+
+	return &planes[0], nil
+}
+
+// FindByID obtiene un plan de mejora por ID
+func (r *Repository) FindByID(idPlan int) (*domain.PlanMejora, error) {
+	data, _, err := r.db.From("plan_mejora").
+		Select("*", "", false).
+		Eq("idPlan", fmt.Sprintf("%d", idPlan)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var planes []domain.PlanMejora
+	if err := json.Unmarshal(data, &planes); err != nil {
+		return nil, err
+	}
+
+	if len(planes) == 0 {
+		return nil, ErrPlanNoEncontrado
+	}
+
+	return &planes[0], nil
+}
+
+// Create crea el plan de mejora de una bodega
+func (r *Repository) Create(plan *domain.PlanMejora) error {
+	planMap := map[string]interface{}{
+		"idBodega":       plan.IdBodega,
+		"idEvaluacion":   plan.IdEvaluacion,
+		"fecha_creacion": time.Now().Format(time.RFC3339),
+	}
+
+	data, _, err := r.db.From("plan_mejora").
+		Insert(planMap, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []domain.PlanMejora
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*plan = result[0]
+	}
+
+	return nil
+}
+
+// UpdateEvaluacion registra la evaluación a partir de la cual se regeneró el plan
+func (r *Repository) UpdateEvaluacion(plan *domain.PlanMejora) error {
+	ahora := time.Now().Format(time.RFC3339)
+	updateMap := map[string]interface{}{
+		"idEvaluacion": plan.IdEvaluacion,
+		"updated_at":   ahora,
+	}
+
+	_, _, err := r.db.From("plan_mejora").
+		Update(updateMap, "minimal", "").
+		Eq("idPlan", fmt.Sprintf("%d", plan.IdPlan)).
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	plan.UpdatedAt = &ahora
+	return nil
+}
+
+// FindItems obtiene los ítems de un plan ordenados por prioridad y posición
+func (r *Repository) FindItems(idPlan int) ([]domain.ItemPlan, error) {
+	data, _, err := r.db.From("item_plan").
+		Select("*", "", false).
+		Eq("idPlan", fmt.Sprintf("%d", idPlan)).
+		Order("prioridad", &ordenAscendente).
+		Order("orden", &ordenAscendente).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var items []domain.ItemPlan
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// FindItemByID obtiene un ítem de un plan por ID
+func (r *Repository) FindItemByID(idItem int) (*domain.ItemPlan, error) {
+	data, _, err := r.db.From("item_plan").
+		Select("*", "", false).
+		Eq("idItem", fmt.Sprintf("%d", idItem)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var items []domain.ItemPlan
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, ErrItemNoEncontrado
+	}
+
+	return &items[0], nil
+}
+
+// ReplaceItems reemplaza todos los ítems de un plan
+func (r *Repository) ReplaceItems(idPlan int, items []domain.ItemPlan) error {
+	_, _, err := r.db.From("item_plan").
+		Delete("minimal", "").
+		Eq("idPlan", fmt.Sprintf("%d", idPlan)).
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	ahora := time.Now().Format(time.RFC3339)
+	filas := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		filas[i] = map[string]interface{}{
+			"idPlan":         idPlan,
+			"idIndicador":    item.IdIndicador,
+			"codigo":         item.Codigo,
+			"indicador":      item.Indicador,
+			"nivel_actual":   item.NivelActual,
+			"nivel_objetivo": item.NivelObjetivo,
+			"accion":         item.Accion,
+			"responsable":    item.Responsable,
+			"fecha_limite":   item.FechaLimite,
+			"prioridad":      item.Prioridad,
+			"orden":          item.Orden,
+			"estado":         item.Estado,
+			"updated_at":     ahora,
+		}
+	}
+
+	_, _, err = r.db.From("item_plan").
+		Insert(filas, false, "", "minimal", "").
+		Execute()
+
+	return err
+}
+
+// UpdateItem actualiza los campos editables de un ítem
+func (r *Repository) UpdateItem(item *domain.ItemPlan) error {
+	updateMap := map[string]interface{}{
+		"accion":       item.Accion,
+		"responsable":  item.Responsable,
+		"fecha_limite": item.FechaLimite,
+		"prioridad":    item.Prioridad,
+		"estado":       item.Estado,
+		"updated_at":   time.Now().Format(time.RFC3339),
+	}
+
+	data, _, err := r.db.From("item_plan").
+		Update(updateMap, "", "").
+		Eq("idItem", fmt.Sprintf("%d", item.IdItem)).
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	var result []domain.ItemPlan
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	if len(result) > 0 {
+		*item = result[0]
+	}
+
+	return nil
+}
+
+// DeleteItem elimina un ítem de un plan
+func (r *Repository) DeleteItem(idItem int) error {
+	_, _, err := r.db.From("item_plan").
+		Delete("minimal", "").
+		Eq("idItem", fmt.Sprintf("%d", idItem)).
+		Execute()
+
+	return err
+}
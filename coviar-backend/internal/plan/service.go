@@ -0,0 +1,318 @@
+// RUTA: coviar-backend/internal/plan/service.go
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+)
+
+// Errores de negocio que el handler traduce a códigos HTTP
+var (
+	ErrPlanNoEncontrado       = errors.New("la bodega todavía no tiene plan de mejora")
+	ErrItemNoEncontrado       = errors.New("ítem del plan de mejora no encontrado")
+	ErrEvaluacionNoFinalizada = errors.New("el plan de mejora se genera a partir de una evaluación finalizada")
+)
+
+// plazos es el tiempo sugerido para cumplir un ítem según su prioridad
+var plazos = map[int]time.Duration{
+	domain.PrioridadAlta:  90 * 24 * time.Hour,
+	domain.PrioridadMedia: 180 * 24 * time.Hour,
+	domain.PrioridadBaja:  365 * 24 * time.Hour,
+}
+
+// responsables sugiere quién debería encargarse de cada capítulo de la guía
+var responsables = map[int]string{
+	1:  "Dirección",
+	2:  "Responsable de sostenibilidad",
+	3:  "Administración y legales",
+	4:  "Relaciones con la comunidad",
+	5:  "Responsable de enoturismo",
+	6:  "Responsable de enoturismo",
+	7:  "Seguridad e higiene",
+	8:  "Recursos humanos",
+	9:  "Responsable de enoturismo",
+	10: "Gestión ambiental",
+	11: "Gestión ambiental",
+	12: "Gestión ambiental",
+	13: "Responsable de enoturismo",
+	14: "Marketing y comercialización",
+}
+
+// responsablePorDefecto se usa para capítulos que no figuran en responsables
+const responsablePorDefecto = "Responsable de sostenibilidad"
+
+// Service contiene la lógica de negocio de los planes de mejora
+type Service struct {
+	repo         *Repository
+	evaluaciones *evaluacion.Service
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, evaluacionService *evaluacion.Service) *Service {
+	return &Service{repo: repo, evaluaciones: evaluacionService}
+}
+
+// Generar arma el plan de mejora de la bodega a partir de una evaluación finalizada: un
+// ítem por cada indicador por debajo del nivel máximo, con el siguiente nivel como
+// objetivo. Si la bodega ya tenía plan, se reemplazan sus ítems conservando el
+// responsable, la fecha límite y el estado de los que siguen teniendo el mismo objetivo.
+func (s *Service) Generar(idEvaluacion int) (*domain.PlanMejora, error) {
+	ev, err := s.evaluaciones.GetByID(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+	if domain.EvaluacionEditable(ev.Estado) {
+		return nil, ErrEvaluacionNoFinalizada
+	}
+
+	brechas, err := s.evaluaciones.GetBrechas(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.repo.FindByBodega(ev.IdBodega)
+	if err != nil && !errors.Is(err, ErrPlanNoEncontrado) {
+		return nil, err
+	}
+
+	anteriores := make(map[string]domain.ItemPlan)
+	if plan == nil {
+		plan = &domain.PlanMejora{IdBodega: ev.IdBodega, IdEvaluacion: idEvaluacion}
+		if err := s.repo.Create(plan); err != nil {
+			return nil, err
+		}
+	} else {
+		items, err := s.repo.FindItems(plan.IdPlan)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			anteriores[claveItem(item.Codigo, item.NivelObjetivo)] = item
+		}
+
+		plan.IdEvaluacion = idEvaluacion
+		if err := s.repo.UpdateEvaluacion(plan); err != nil {
+			return nil, err
+		}
+	}
+
+	items := armarItems(brechas, time.Now())
+	for i := range items {
+		if anterior, ok := anteriores[claveItem(items[i].Codigo, items[i].NivelObjetivo)]; ok {
+			items[i].Responsable = anterior.Responsable
+			items[i].FechaLimite = anterior.FechaLimite
+			items[i].Prioridad = anterior.Prioridad
+			items[i].Estado = anterior.Estado
+		}
+	}
+
+	if err := s.repo.ReplaceItems(plan.IdPlan, items); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(plan.IdPlan)
+}
+
+// Sugerir arma los ítems que tendría el plan de mejora generado a partir de una
+// evaluación, sin guardarlos
+func (s *Service) Sugerir(idEvaluacion int) ([]domain.ItemPlan, error) {
+	brechas, err := s.evaluaciones.GetBrechas(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	return armarItems(brechas, time.Now()), nil
+}
+
+// GetByBodega obtiene el plan de mejora de una bodega con sus ítems
+func (s *Service) GetByBodega(idBodega int) (*domain.PlanMejora, error) {
+	if idBodega <= 0 {
+		return nil, fmt.Errorf("ID de bodega inválido")
+	}
+
+	plan, err := s.repo.FindByBodega(idBodega)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Items, err = s.repo.FindItems(plan.IdPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// GetByID obtiene un plan de mejora con sus ítems
+func (s *Service) GetByID(idPlan int) (*domain.PlanMejora, error) {
+	plan, err := s.repo.FindByID(idPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Items, err = s.repo.FindItems(plan.IdPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// GetItem obtiene un ítem de plan por ID
+func (s *Service) GetItem(idItem int) (*domain.ItemPlan, error) {
+	if idItem <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+
+	return s.repo.FindItemByID(idItem)
+}
+
+// ActualizarItem modifica la acción, el responsable, la fecha límite, la prioridad o el
+// estado de un ítem. Los campos en nil no se modifican.
+func (s *Service) ActualizarItem(idItem int, cambios CambiosItem) (*domain.ItemPlan, error) {
+	item, err := s.GetItem(idItem)
+	if err != nil {
+		return nil, err
+	}
+
+	if cambios.Accion != nil {
+		accion := strings.TrimSpace(*cambios.Accion)
+		if accion == "" {
+			return nil, fmt.Errorf("la acción no puede quedar vacía")
+		}
+		item.Accion = accion
+	}
+
+	if cambios.Responsable != nil {
+		responsable := strings.TrimSpace(*cambios.Responsable)
+		item.Responsable = &responsable
+		if responsable == "" {
+			item.Responsable = nil
+		}
+	}
+
+	if cambios.FechaLimite != nil {
+		fecha := strings.TrimSpace(*cambios.FechaLimite)
+		if fecha == "" {
+			item.FechaLimite = nil
+		} else {
+			if _, err := time.Parse("2006-01-02", fecha); err != nil {
+				return nil, fmt.Errorf("la fecha límite debe tener el formato AAAA-MM-DD")
+			}
+			item.FechaLimite = &fecha
+		}
+	}
+
+	if cambios.Prioridad != nil {
+		if *cambios.Prioridad < domain.PrioridadAlta || *cambios.Prioridad > domain.PrioridadBaja {
+			return nil, fmt.Errorf("la prioridad debe estar entre %d y %d", domain.PrioridadAlta, domain.PrioridadBaja)
+		}
+		item.Prioridad = *cambios.Prioridad
+	}
+
+	if cambios.Estado != nil {
+		switch *cambios.Estado {
+		case domain.ItemPendiente, domain.ItemEnCurso, domain.ItemCompletado:
+			item.Estado = *cambios.Estado
+		default:
+			return nil, fmt.Errorf("estado inválido: debe ser %s, %s o %s", domain.ItemPendiente, domain.ItemEnCurso, domain.ItemCompletado)
+		}
+	}
+
+	if err := s.repo.UpdateItem(item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// EliminarItem quita un ítem del plan
+func (s *Service) EliminarItem(idItem int) error {
+	if _, err := s.GetItem(idItem); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteItem(idItem)
+}
+
+// CambiosItem son los campos editables de un ítem del plan
+type CambiosItem struct {
+	Accion      *string `json:"accion"`
+	Responsable *string `json:"responsable"`
+	FechaLimite *string `json:"fecha_limite"`
+	Prioridad   *int    `json:"prioridad"`
+	Estado      *string `json:"estado"`
+}
+
+// armarItems convierte las brechas en ítems priorizados: primero los indicadores con
+// menor nivel y, a igual nivel, los de los capítulos con peor porcentaje
+func armarItems(brechas []evaluacion.Brecha, desde time.Time) []domain.ItemPlan {
+	sort.SliceStable(brechas, func(a, b int) bool {
+		if brechas[a].Nivel != brechas[b].Nivel {
+			return brechas[a].Nivel < brechas[b].Nivel
+		}
+		if brechas[a].PorcentajeCapitulo != brechas[b].PorcentajeCapitulo {
+			return brechas[a].PorcentajeCapitulo < brechas[b].PorcentajeCapitulo
+		}
+		return domain.CompararCodigos(brechas[a].Indicador.Codigo, brechas[b].Indicador.Codigo) < 0
+	})
+
+	items := make([]domain.ItemPlan, len(brechas))
+	for i, brecha := range brechas {
+		objetivo := brecha.Nivel + 1
+		prioridad := prioridadPorNivel(brecha.Nivel)
+
+		responsable, ok := responsables[brecha.Capitulo.Numero]
+		if !ok {
+			responsable = responsablePorDefecto
+		}
+		fechaLimite := desde.Add(plazos[prioridad]).Format("2006-01-02")
+
+		items[i] = domain.ItemPlan{
+			IdIndicador:   brecha.Indicador.IdIndicador,
+			Codigo:        brecha.Indicador.Codigo,
+			Indicador:     brecha.Indicador.Nombre,
+			NivelActual:   brecha.Nivel,
+			NivelObjetivo: objetivo,
+			Accion:        descripcionNivel(brecha.Indicador, objetivo),
+			Responsable:   &responsable,
+			FechaLimite:   &fechaLimite,
+			Prioridad:     prioridad,
+			Orden:         i + 1,
+			Estado:        domain.ItemPendiente,
+		}
+	}
+
+	return items
+}
+
+func prioridadPorNivel(nivel int) int {
+	switch {
+	case nivel <= 0:
+		return domain.PrioridadAlta
+	case nivel == 1:
+		return domain.PrioridadMedia
+	default:
+		return domain.PrioridadBaja
+	}
+}
+
+// descripcionNivel obtiene del catálogo el texto del nivel objetivo de un indicador
+func descripcionNivel(indicador domain.Indicador, nivel int) string {
+	for _, n := range indicador.Niveles {
+		if n.Nivel == nivel {
+			return n.Descripcion
+		}
+	}
+	return fmt.Sprintf("Alcanzar el nivel %d en el indicador %s", nivel, indicador.Codigo)
+}
+
+func claveItem(codigo string, nivelObjetivo int) string {
+	return fmt.Sprintf("%s/%d", codigo, nivelObjetivo)
+}
@@ -0,0 +1,56 @@
+// RUTA: coviar-backend/internal/platform/database/tandas.go
+package database
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/supabase-community/postgrest-go"
+)
+
+// LimiteFilas es la cantidad de filas que se piden por consulta. PostgREST limita por
+// defecto las respuestas a 1000 filas, así que las lecturas largas se hacen por tandas.
+const LimiteFilas = 1000
+
+// OrdenAscendente ordena los resultados de menor a mayor
+var OrdenAscendente = postgrest.OrderOpts{Ascending: true}
+
+// LeerPorTandas lee todas las filas de una consulta en tandas de LimiteFilas, paginando
+// por la columna de ID para no depender de desplazamientos. consulta arma la consulta
+// con sus filtros y se llama una vez por tanda; idDe devuelve el ID de una fila.
+func LeerPorTandas[T any](consulta func() *postgrest.FilterBuilder, columnaId string, idDe func(*T) int) ([]T, error) {
+	var filas []T
+	desdeId := 0
+
+	for {
+		data, _, err := consulta().
+			Gt(columnaId, strconv.Itoa(desdeId)).
+			Order(columnaId, &OrdenAscendente).
+			Limit(LimiteFilas, "").
+			Execute()
+
+		if err != nil {
+			return nil, err
+		}
+
+		var tanda []T
+		if err := json.Unmarshal(data, &tanda); err != nil {
+			return nil, err
+		}
+
+		filas = append(filas, tanda...)
+		if len(tanda) < LimiteFilas {
+			return filas, nil
+		}
+		desdeId = idDe(&tanda[len(tanda)-1])
+	}
+}
+
+// ToStrings convierte una lista de IDs en los valores de un filtro In
+func ToStrings(ids []int) []string {
+	valores := make([]string, len(ids))
+	for i, id := range ids {
+		valores[i] = strconv.Itoa(id)
+	}
+	return valores
+}
@@ -0,0 +1,18 @@
+// RUTA: coviar-backend/internal/platform/email/email.go
+package email
+
+import "log"
+
+// Mailer abstrae el envío de correos HTML (servidor SMTP, registro en consola, etc.)
+type Mailer interface {
+	Enviar(destinatario, asunto, html string) error
+}
+
+// Log solo registra los correos en el log. Se usa cuando no hay SMTP configurado.
+type Log struct{}
+
+// Enviar registra el correo sin enviarlo
+func (Log) Enviar(destinatario, asunto, html string) error {
+	log.Printf("📧 (sin SMTP) Correo a %s: %s", destinatario, asunto)
+	return nil
+}
@@ -0,0 +1,37 @@
+// RUTA: coviar-backend/internal/platform/email/smtp.go
+package email
+
+import (
+	"fmt"
+	"net/smtp"
+)
+
+// SMTP envía los correos a través de un servidor SMTP con autenticación PLAIN
+type SMTP struct {
+	host     string
+	port     string
+	usuario  string
+	password string
+}
+
+// NewSMTP crea el cliente SMTP. El remitente es el mismo usuario con el que se autentica.
+func NewSMTP(host, port, usuario, password string) *SMTP {
+	return &SMTP{host: host, port: port, usuario: usuario, password: password}
+}
+
+// Enviar envía un correo HTML (smtp.SendMail maneja STARTTLS automáticamente)
+func (s *SMTP) Enviar(destinatario, asunto, html string) error {
+	mensaje := []byte("To: " + destinatario + "\r\n" +
+		"Subject: " + asunto + "\r\n" +
+		"MIME-version: 1.0;\r\nContent-Type: text/html; charset=\"UTF-8\";\r\n\r\n" +
+		html)
+
+	auth := smtp.PlainAuth("", s.usuario, s.password, s.host)
+	addr := fmt.Sprintf("%s:%s", s.host, s.port)
+
+	if err := smtp.SendMail(addr, auth, s.usuario, []string{destinatario}, mensaje); err != nil {
+		return fmt.Errorf("error enviando email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// RUTA: coviar-backend/internal/platform/storage/local.go
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local guarda los archivos en un directorio del disco del servidor
+type Local struct {
+	dir string
+}
+
+// NewLocal crea el almacenamiento local, creando el directorio base si no existe
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("no se pudo crear el directorio de almacenamiento: %w", err)
+	}
+
+	return &Local{dir: dir}, nil
+}
+
+// Guardar escribe el contenido en la ruta indicada, creando los subdirectorios necesarios
+func (l *Local) Guardar(ruta string, contenido io.Reader) error {
+	destino, err := l.resolver(ruta)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destino), 0o750); err != nil {
+		return err
+	}
+
+	archivo, err := os.OpenFile(destino, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(archivo, contenido); err != nil {
+		archivo.Close()
+		os.Remove(destino)
+		return err
+	}
+
+	return archivo.Close()
+}
+
+// Abrir abre el archivo de la ruta indicada para lectura
+func (l *Local) Abrir(ruta string) (io.ReadCloser, error) {
+	origen, err := l.resolver(ruta)
+	if err != nil {
+		return nil, err
+	}
+
+	archivo, err := os.Open(origen)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrArchivoNoEncontrado
+	}
+
+	return archivo, err
+}
+
+// Eliminar borra el archivo de la ruta indicada
+func (l *Local) Eliminar(ruta string) error {
+	origen, err := l.resolver(ruta)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(origen)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrArchivoNoEncontrado
+	}
+
+	return err
+}
+
+// resolver convierte una ruta relativa en una ruta dentro del directorio base,
+// rechazando rutas que intenten salir de él
+func (l *Local) resolver(ruta string) (string, error) {
+	limpia := filepath.Clean(filepath.FromSlash(ruta))
+	if filepath.IsAbs(limpia) || limpia == "." || strings.HasPrefix(limpia, ".."+string(filepath.Separator)) || limpia == ".." {
+		return "", fmt.Errorf("ruta de archivo inválida: %s", ruta)
+	}
+
+	return filepath.Join(l.dir, limpia), nil
+}
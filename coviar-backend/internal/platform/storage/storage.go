@@ -0,0 +1,18 @@
+// RUTA: coviar-backend/internal/platform/storage/storage.go
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrArchivoNoEncontrado se devuelve cuando la ruta pedida no existe en el almacenamiento
+var ErrArchivoNoEncontrado = errors.New("archivo no encontrado")
+
+// Storage abstrae dónde se guardan los archivos subidos (disco local, bucket, etc.).
+// Las rutas son relativas y usan "/" como separador.
+type Storage interface {
+	Guardar(ruta string, contenido io.Reader) error
+	Abrir(ruta string) (io.ReadCloser, error)
+	Eliminar(ruta string) error
+}
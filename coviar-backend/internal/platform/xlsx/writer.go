@@ -0,0 +1,158 @@
+// RUTA: coviar-backend/internal/platform/xlsx/writer.go
+// Package xlsx escribe planillas de Excel (.xlsx) de una sola hoja fila por fila, sin
+// mantener la planilla en memoria. Solo admite texto y números, que es lo que
+// necesitan las exportaciones.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Partes fijas del paquete OOXML
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+
+	rels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+
+	// Estilo 1: negrita, para la fila de encabezados
+	styles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border/></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs></styleSheet>`
+
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	sheetInicio = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	sheetFin = `</sheetData></worksheet>`
+)
+
+// Writer escribe una planilla de una sola hoja
+type Writer struct {
+	zip   *zip.Writer
+	hoja  *bufio.Writer
+	filas int
+}
+
+// NewWriter crea la planilla y deja abierta la hoja para escribir filas
+func NewWriter(w io.Writer, nombreHoja string) (*Writer, error) {
+	z := zip.NewWriter(w)
+
+	var nombre strings.Builder
+	xml.EscapeText(&nombre, []byte(nombreHoja))
+
+	partes := []struct{ ruta, contenido string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, nombre.String())},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+		{"xl/styles.xml", styles},
+	}
+	for _, parte := range partes {
+		f, err := z.Create(parte.ruta)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, parte.contenido); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	hoja := bufio.NewWriter(f)
+	if _, err := hoja.WriteString(sheetInicio); err != nil {
+		return nil, err
+	}
+
+	return &Writer{zip: z, hoja: hoja}, nil
+}
+
+// WriteHeader escribe una fila de encabezados en negrita
+func (w *Writer) WriteHeader(titulos []string) error {
+	valores := make([]interface{}, len(titulos))
+	for i, titulo := range titulos {
+		valores[i] = titulo
+	}
+	return w.escribirFila(valores, true)
+}
+
+// WriteRow escribe una fila. Los enteros y decimales se guardan como números, nil como
+// celda vacía y cualquier otro valor como texto.
+func (w *Writer) WriteRow(valores []interface{}) error {
+	return w.escribirFila(valores, false)
+}
+
+// Flush envía al destino lo escrito hasta el momento
+func (w *Writer) Flush() error {
+	if err := w.hoja.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Flush()
+}
+
+// Close cierra la hoja y el archivo. No cierra el destino.
+func (w *Writer) Close() error {
+	if _, err := w.hoja.WriteString(sheetFin); err != nil {
+		return err
+	}
+	if err := w.hoja.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func (w *Writer) escribirFila(valores []interface{}, negrita bool) error {
+	w.filas++
+	fmt.Fprintf(w.hoja, `<row r="%d">`, w.filas)
+
+	for i, valor := range valores {
+		ref := columna(i) + strconv.Itoa(w.filas)
+		estilo := ""
+		if negrita {
+			estilo = ` s="1"`
+		}
+
+		switch v := valor.(type) {
+		case nil:
+			continue
+		case int:
+			fmt.Fprintf(w.hoja, `<c r="%s"%s><v>%d</v></c>`, ref, estilo, v)
+		case int64:
+			fmt.Fprintf(w.hoja, `<c r="%s"%s><v>%d</v></c>`, ref, estilo, v)
+		case float64:
+			fmt.Fprintf(w.hoja, `<c r="%s"%s><v>%s</v></c>`, ref, estilo, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			fmt.Fprintf(w.hoja, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">`, ref, estilo)
+			xml.EscapeText(w.hoja, []byte(fmt.Sprint(v)))
+			w.hoja.WriteString(`</t></is></c>`)
+		}
+	}
+
+	_, err := w.hoja.WriteString(`</row>`)
+	return err
+}
+
+// columna convierte un índice (desde 0) en la letra de columna de Excel (A, B, ..., AA)
+func columna(indice int) string {
+	letras := ""
+	for indice >= 0 {
+		letras = string(rune('A'+indice%26)) + letras
+		indice = indice/26 - 1
+	}
+	return letras
+}
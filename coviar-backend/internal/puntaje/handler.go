@@ -0,0 +1,75 @@
+// RUTA: coviar-backend/internal/puntaje/handler.go
+package puntaje
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// Handler maneja las peticiones HTTP para los niveles de sostenibilidad
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListNiveles maneja GET /api/niveles-sostenibilidad?idSegmento={id}
+func (h *Handler) ListNiveles(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	idSegmento := 0
+	if param := r.URL.Query().Get("idSegmento"); param != "" {
+		var err error
+		idSegmento, err = strconv.Atoi(param)
+		if err != nil {
+			sendError(w, "ID de segmento inválido", http.StatusBadRequest)
+			return
+		}
+	}
+
+	niveles, err := h.service.GetNiveles(idSegmento)
+	if err != nil {
+		log.Printf("Error al obtener niveles de sostenibilidad: %v", err)
+		sendError(w, "Error al obtener niveles de sostenibilidad", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, niveles)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
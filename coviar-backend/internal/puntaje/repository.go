@@ -0,0 +1,103 @@
+// RUTA: coviar-backend/internal/puntaje/repository.go
+package puntaje
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// Repository maneja el acceso a las definiciones de niveles de sostenibilidad
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindNiveles obtiene los niveles de sostenibilidad de un segmento ordenados por puntaje mínimo.
+// Con idSegmento = 0 obtiene los niveles generales (sin segmento asignado).
+func (r *Repository) FindNiveles(idSegmento int) ([]domain.NivelSostenibilidad, error) {
+	query := r.db.From("nivel_sostenibilidad").
+		Select("*", "", false)
+
+	if idSegmento > 0 {
+		query = query.Eq("idSegmento", fmt.Sprintf("%d", idSegmento))
+	} else {
+		query = query.Is("idSegmento", "null")
+	}
+
+	data, _, err := query.
+		Order("puntaje_minimo", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var niveles []domain.NivelSostenibilidad
+	if err := json.Unmarshal(data, &niveles); err != nil {
+		return nil, err
+	}
+
+	return niveles, nil
+}
+
+// FindPuntajesCapitulo obtiene los puntajes por capítulo guardados de una evaluación
+func (r *Repository) FindPuntajesCapitulo(idEvaluacion int) ([]domain.PuntajeCapitulo, error) {
+	data, _, err := r.db.From("puntaje_capitulo").
+		Select("*", "", false).
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion)).
+		Order("numero_capitulo", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var puntajes []domain.PuntajeCapitulo
+	if err := json.Unmarshal(data, &puntajes); err != nil {
+		return nil, err
+	}
+
+	return puntajes, nil
+}
+
+// ReplacePuntajesCapitulo reemplaza los puntajes por capítulo guardados de una evaluación
+func (r *Repository) ReplacePuntajesCapitulo(idEvaluacion int, puntajes []domain.PuntajeCapitulo) error {
+	_, _, err := r.db.From("puntaje_capitulo").
+		Delete("minimal", "").
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion)).
+		Execute()
+
+	if err != nil {
+		return err
+	}
+
+	if len(puntajes) == 0 {
+		return nil
+	}
+
+	filas := make([]map[string]interface{}, len(puntajes))
+	for i, puntaje := range puntajes {
+		filas[i] = map[string]interface{}{
+			"idEvaluacion":    idEvaluacion,
+			"numero_capitulo": puntaje.NumeroCapitulo,
+			"nombre":          puntaje.Nombre,
+			"puntaje":         puntaje.Puntaje,
+			"puntaje_maximo":  puntaje.PuntajeMaximo,
+			"porcentaje":      puntaje.Porcentaje,
+		}
+	}
+
+	_, _, err = r.db.From("puntaje_capitulo").
+		Insert(filas, false, "", "minimal", "").
+		Execute()
+
+	return err
+}
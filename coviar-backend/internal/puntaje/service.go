@@ -0,0 +1,162 @@
+// RUTA: coviar-backend/internal/puntaje/service.go
+package puntaje
+
+import (
+	"math"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// Resultado es el puntaje calculado para un conjunto de respuestas
+type Resultado struct {
+	PuntajeTotal  int                         `json:"puntaje_total"`
+	PuntajeMaximo int                         `json:"puntaje_maximo"`
+	Nivel         *domain.NivelSostenibilidad `json:"nivel"`
+	Capitulos     []domain.PuntajeCapitulo    `json:"capitulos"`
+}
+
+// Service calcula el puntaje oficial de una evaluación
+type Service struct {
+	repo *Repository
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// GetNiveles obtiene los umbrales que aplican a un segmento. Si el segmento no
+// tiene umbrales propios (o idSegmento = 0) se devuelven los umbrales generales.
+func (s *Service) GetNiveles(idSegmento int) ([]domain.NivelSostenibilidad, error) {
+	if idSegmento > 0 {
+		niveles, err := s.repo.FindNiveles(idSegmento)
+		if err != nil {
+			return nil, err
+		}
+		if len(niveles) > 0 {
+			return niveles, nil
+		}
+	}
+
+	return s.repo.FindNiveles(0)
+}
+
+// UbicarNivel obtiene un nivel de sostenibilidad de los umbrales del segmento junto
+// con su posición en la escala (1 = el más bajo). Si no se encuentra devuelve nil y 0.
+func (s *Service) UbicarNivel(idSegmento int, idNvSos int) (*domain.NivelSostenibilidad, int, error) {
+	niveles, err := s.GetNiveles(idSegmento)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range niveles {
+		if niveles[i].IdNvSos == idNvSos {
+			return &niveles[i], i + 1, nil
+		}
+	}
+
+	return nil, 0, nil
+}
+
+// Calcular suma los niveles respondidos de los indicadores aplicables, capítulo por
+// capítulo, y asigna el nivel de sostenibilidad del segmento cuyo rango contiene al
+// total. Si ningún rango lo contiene, Nivel queda en nil. El puntaje máximo se obtiene
+// de la cantidad de indicadores aplicables (niveles: idIndicador -> nivel respondido).
+// Si el segmento usa los umbrales generales, se escalan a su puntaje máximo.
+func (s *Service) Calcular(capitulos []domain.Capitulo, niveles map[int]int, idSegmento int) (*Resultado, error) {
+	umbrales, err := s.GetNiveles(idSegmento)
+	if err != nil {
+		return nil, err
+	}
+
+	resultado := &Resultado{Capitulos: s.CalcularCapitulos(capitulos, niveles)}
+	for _, capitulo := range resultado.Capitulos {
+		resultado.PuntajeTotal += capitulo.Puntaje
+		resultado.PuntajeMaximo += capitulo.PuntajeMaximo
+	}
+
+	resultado.Nivel = nivelPara(umbrales, resultado.PuntajeTotal, resultado.PuntajeMaximo)
+
+	return resultado, nil
+}
+
+// nivelPara devuelve el nivel cuyo rango contiene al puntaje, o nil si ninguno lo
+// contiene. Los umbrales generales corresponden al catálogo completo: en un segmento con
+// menos indicadores aplicables los niveles más altos serían inalcanzables, así que se
+// escalan a su puntaje máximo.
+func nivelPara(umbrales []domain.NivelSostenibilidad, puntajeTotal int, puntajeMaximo int) *domain.NivelSostenibilidad {
+	if len(umbrales) > 0 && umbrales[0].IdSegmento == nil {
+		umbrales = escalarUmbrales(umbrales, puntajeMaximo)
+	}
+
+	for i := range umbrales {
+		if umbrales[i].Contiene(puntajeTotal) {
+			return &umbrales[i]
+		}
+	}
+
+	return nil
+}
+
+// escalarUmbrales lleva los umbrales (ordenados por puntaje mínimo) a la escala de otro
+// puntaje máximo, conservando la proporción de cada rango. Se redondea hacia arriba para
+// no exigir menos que los umbrales originales, y los rangos siguen siendo contiguos.
+func escalarUmbrales(umbrales []domain.NivelSostenibilidad, puntajeMaximo int) []domain.NivelSostenibilidad {
+	maximoGeneral := umbrales[len(umbrales)-1].PuntajeMaximo
+	if puntajeMaximo <= 0 || maximoGeneral <= 0 || puntajeMaximo == maximoGeneral {
+		return umbrales
+	}
+
+	escalar := func(puntaje int) int {
+		return int(math.Ceil(float64(puntaje) * float64(puntajeMaximo) / float64(maximoGeneral)))
+	}
+
+	escalados := make([]domain.NivelSostenibilidad, len(umbrales))
+	for i, umbral := range umbrales {
+		umbral.PuntajeMinimo = escalar(umbral.PuntajeMinimo)
+		umbral.PuntajeMaximo = escalar(umbral.PuntajeMaximo+1) - 1
+		escalados[i] = umbral
+	}
+	escalados[len(escalados)-1].PuntajeMaximo = puntajeMaximo
+
+	return escalados
+}
+
+// CalcularCapitulos calcula el puntaje, el máximo y el porcentaje de cada capítulo a
+// partir de sus indicadores aplicables y del nivel respondido en cada uno
+// (niveles: idIndicador -> nivel; los indicadores sin respuesta suman 0)
+func (s *Service) CalcularCapitulos(capitulos []domain.Capitulo, niveles map[int]int) []domain.PuntajeCapitulo {
+	puntajes := make([]domain.PuntajeCapitulo, 0, len(capitulos))
+	for _, capitulo := range capitulos {
+		puntaje := domain.PuntajeCapitulo{
+			NumeroCapitulo: capitulo.Numero,
+			Nombre:         capitulo.Nombre,
+		}
+		for _, indicador := range capitulo.Indicadores {
+			puntaje.Puntaje += niveles[indicador.IdIndicador]
+			puntaje.PuntajeMaximo += domain.NivelMaximo
+		}
+		puntaje.Porcentaje = Porcentaje(puntaje.Puntaje, puntaje.PuntajeMaximo)
+		puntajes = append(puntajes, puntaje)
+	}
+
+	return puntajes
+}
+
+// GetPuntajesCapitulo obtiene los puntajes por capítulo guardados al finalizar una evaluación
+func (s *Service) GetPuntajesCapitulo(idEvaluacion int) ([]domain.PuntajeCapitulo, error) {
+	return s.repo.FindPuntajesCapitulo(idEvaluacion)
+}
+
+// GuardarPuntajesCapitulo guarda (reemplazando los anteriores) los puntajes por capítulo de una evaluación
+func (s *Service) GuardarPuntajesCapitulo(idEvaluacion int, puntajes []domain.PuntajeCapitulo) error {
+	return s.repo.ReplacePuntajesCapitulo(idEvaluacion, puntajes)
+}
+
+// Porcentaje calcula qué porcentaje del máximo representa un puntaje, con un decimal
+func Porcentaje(puntaje int, maximo int) float64 {
+	if maximo <= 0 {
+		return 0
+	}
+	return math.Round(float64(puntaje)*1000/float64(maximo)) / 10
+}
@@ -0,0 +1,92 @@
+package puntaje
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// umbralesGenerales son los umbrales iniciales de scripts/002, para el catálogo completo
+func umbralesGenerales() []domain.NivelSostenibilidad {
+	return []domain.NivelSostenibilidad{
+		{IdNvSos: 1, Nombre: "Nivel mínimo", PuntajeMinimo: 42, PuntajeMaximo: 93},
+		{IdNvSos: 2, Nombre: "Nivel medio", PuntajeMinimo: 94, PuntajeMaximo: 112},
+		{IdNvSos: 3, Nombre: "Nivel alto", PuntajeMinimo: 113, PuntajeMaximo: 126},
+	}
+}
+
+func TestEscalarUmbrales(t *testing.T) {
+	tests := []struct {
+		nombre        string
+		puntajeMaximo int
+		rangos        [][2]int // mínimo y máximo de cada nivel
+	}{
+		{"catálogo completo", 126, [][2]int{{42, 93}, {94, 112}, {113, 126}}},
+		{"mitad del catálogo", 63, [][2]int{{21, 46}, {47, 56}, {57, 63}}},
+		{"segmento con 30 indicadores", 90, [][2]int{{30, 67}, {68, 80}, {81, 90}}},
+		{"sin indicadores", 0, [][2]int{{42, 93}, {94, 112}, {113, 126}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nombre, func(t *testing.T) {
+			escalados := escalarUmbrales(umbralesGenerales(), tt.puntajeMaximo)
+
+			rangos := make([][2]int, len(escalados))
+			for i, umbral := range escalados {
+				rangos[i] = [2]int{umbral.PuntajeMinimo, umbral.PuntajeMaximo}
+			}
+			if !reflect.DeepEqual(rangos, tt.rangos) {
+				t.Fatalf("escalarUmbrales(%d) = %v; se esperaba %v", tt.puntajeMaximo, rangos, tt.rangos)
+			}
+
+			// Los rangos tienen que ser contiguos: ningún puntaje queda entre dos niveles
+			for i := 1; i < len(escalados); i++ {
+				if escalados[i].PuntajeMinimo != escalados[i-1].PuntajeMaximo+1 {
+					t.Fatalf("los rangos %v y %v no son contiguos", rangos[i-1], rangos[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNivelPara(t *testing.T) {
+	idSegmento := 4
+	propios := []domain.NivelSostenibilidad{
+		{IdNvSos: 10, IdSegmento: &idSegmento, Nombre: "Nivel mínimo", PuntajeMinimo: 20, PuntajeMaximo: 40},
+		{IdNvSos: 11, IdSegmento: &idSegmento, Nombre: "Nivel alto", PuntajeMinimo: 41, PuntajeMaximo: 60},
+	}
+
+	tests := []struct {
+		nombre        string
+		umbrales      []domain.NivelSostenibilidad
+		puntajeTotal  int
+		puntajeMaximo int
+		idNvSos       int // 0 = sin nivel
+	}{
+		{"debajo del mínimo", umbralesGenerales(), 41, 126, 0},
+		{"justo en el mínimo", umbralesGenerales(), 42, 126, 1},
+		{"límite entre mínimo y medio", umbralesGenerales(), 94, 126, 2},
+		{"puntaje perfecto", umbralesGenerales(), 126, 126, 3},
+		{"segmento chico, debajo del mínimo escalado", umbralesGenerales(), 20, 63, 0},
+		{"segmento chico, mínimo escalado", umbralesGenerales(), 21, 63, 1},
+		{"segmento chico, puntaje perfecto", umbralesGenerales(), 63, 63, 3},
+		{"umbrales propios sin escalar", propios, 41, 90, 11},
+		{"umbrales propios, fuera de rango", propios, 61, 90, 0},
+		{"sin umbrales", nil, 100, 126, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nombre, func(t *testing.T) {
+			nivel := nivelPara(tt.umbrales, tt.puntajeTotal, tt.puntajeMaximo)
+
+			idNvSos := 0
+			if nivel != nil {
+				idNvSos = nivel.IdNvSos
+			}
+			if idNvSos != tt.idNvSos {
+				t.Fatalf("nivelPara(%d de %d) = nivel %d; se esperaba %d", tt.puntajeTotal, tt.puntajeMaximo, idNvSos, tt.idNvSos)
+			}
+		})
+	}
+}
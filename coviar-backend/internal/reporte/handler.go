@@ -0,0 +1,95 @@
+// RUTA: coviar-backend/internal/reporte/handler.go
+package reporte
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carli/coviar-backend/internal/bodega"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+)
+
+// Handler maneja las peticiones HTTP de los informes
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// EsRutaReporte indica si la ruta corresponde al informe PDF de una evaluación
+func EsRutaReporte(path string) bool {
+	return strings.HasPrefix(path, "/api/evaluaciones/") && strings.HasSuffix(path, "/reporte.pdf")
+}
+
+// GetReportePDF maneja GET /api/evaluaciones/{id}/reporte.pdf
+func (h *Handler) GetReportePDF(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/evaluaciones/"), "/reporte.pdf")
+	id, err := strconv.Atoi(path)
+	if err != nil {
+		sendError(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	reporte, err := h.service.Armar(id)
+	if err != nil {
+		log.Printf("Error al armar el informe: %v", err)
+		sendServiceError(w, err, "Error al generar el informe", http.StatusInternalServerError)
+		return
+	}
+
+	// Se genera en memoria para poder responder con un error si falla a mitad de camino
+	var buf bytes.Buffer
+	if err := RenderPDF(&buf, reporte); err != nil {
+		log.Printf("Error al generar el informe: %v", err)
+		sendError(w, "Error al generar el informe", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"informe-evaluacion-%d.pdf\"", id))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.Header().Set("Cache-Control", "private, no-store")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("Error al enviar el informe %d: %v", id, err)
+	}
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+// sendServiceError traduce los errores de negocio del servicio al código HTTP adecuado
+func sendServiceError(w http.ResponseWriter, err error, fallback string, fallbackStatus int) {
+	switch {
+	case errors.Is(err, evaluacion.ErrEvaluacionNoEncontrada), errors.Is(err, bodega.ErrBodegaNoEncontrada):
+		sendError(w, err.Error(), http.StatusNotFound)
+	default:
+		sendError(w, fallback, fallbackStatus)
+	}
+}
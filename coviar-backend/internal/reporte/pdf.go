@@ -0,0 +1,371 @@
+// RUTA: coviar-backend/internal/reporte/pdf.go
+package reporte
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/go-pdf/fpdf"
+	"rsc.io/qr"
+)
+
+// Colores institucionales de COVIAR (RGB)
+var (
+	colorVino      = [3]int{114, 47, 55}
+	colorVinoSuave = [3]int{243, 232, 234}
+	colorTexto     = [3]int{40, 40, 40}
+	colorGris      = [3]int{120, 120, 120}
+)
+
+// Medidas de la página A4 en milímetros
+const (
+	margen     = 15.0
+	anchoUtil  = 210.0 - 2*margen
+	altoLinea  = 5.5
+	altoTitulo = 8.0
+)
+
+// estadosItem traduce el estado de un ítem del plan para el informe
+var estadosItem = map[string]string{
+	domain.ItemPendiente:  "Pendiente",
+	domain.ItemEnCurso:    "En curso",
+	domain.ItemCompletado: "Completado",
+}
+
+// RenderPDF escribe el informe de una evaluación en formato PDF. No accede a la base de
+// datos ni a la red, por lo que puede usarse con un Reporte armado a mano.
+func RenderPDF(w io.Writer, reporte *Reporte) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margen, 28, margen)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetTitle("Informe de sostenibilidad enoturística", true)
+	pdf.SetAuthor("COVIAR", true)
+	pdf.SetCreationDate(reporte.FechaEmision)
+
+	// Las fuentes estándar usan cp1252: se traducen los textos UTF-8 (acentos, ñ)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	r := &renderizador{pdf: pdf, tr: tr}
+
+	pdf.SetHeaderFunc(r.encabezado)
+	pdf.SetFooterFunc(func() { r.pie(reporte.FechaEmision) })
+	pdf.AliasNbPages("")
+	pdf.AddPage()
+
+	r.datosBodega(reporte)
+	r.resumen(reporte)
+	r.certificado(reporte)
+	r.capitulos(reporte)
+	r.indicadores(reporte)
+	r.mejoras(reporte)
+
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("error al generar el PDF: %w", err)
+	}
+
+	return pdf.Output(w)
+}
+
+type renderizador struct {
+	pdf *fpdf.Fpdf
+	tr  func(string) string
+}
+
+func (r *renderizador) color(c [3]int) {
+	r.pdf.SetTextColor(c[0], c[1], c[2])
+}
+
+func (r *renderizador) relleno(c [3]int) {
+	r.pdf.SetFillColor(c[0], c[1], c[2])
+}
+
+func (r *renderizador) encabezado() {
+	r.relleno(colorVino)
+	r.pdf.Rect(0, 0, 210, 18, "F")
+	r.pdf.SetXY(margen, 5)
+	r.pdf.SetFont("Helvetica", "B", 16)
+	r.pdf.SetTextColor(255, 255, 255)
+	r.pdf.CellFormat(40, 8, "COVIAR", "", 0, "L", false, 0, "")
+	r.pdf.SetFont("Helvetica", "", 10)
+	r.pdf.CellFormat(anchoUtil-40, 8, r.tr("Informe de sostenibilidad enoturística"), "", 0, "R", false, 0, "")
+	r.pdf.SetY(28)
+	r.color(colorTexto)
+}
+
+func (r *renderizador) pie(emision time.Time) {
+	r.pdf.SetY(-15)
+	r.pdf.SetFont("Helvetica", "", 8)
+	r.color(colorGris)
+	r.pdf.CellFormat(anchoUtil/2, 6, r.tr("Emitido el "+emision.Format("02/01/2006")), "", 0, "L", false, 0, "")
+	r.pdf.CellFormat(anchoUtil/2, 6, r.tr(fmt.Sprintf("Página %d de {nb}", r.pdf.PageNo())), "", 0, "R", false, 0, "")
+}
+
+func (r *renderizador) titulo(texto string) {
+	r.pdf.Ln(3)
+	r.pdf.SetFont("Helvetica", "B", 13)
+	r.color(colorVino)
+	r.pdf.CellFormat(anchoUtil, altoTitulo, r.tr(texto), "B", 1, "L", false, 0, "")
+	r.pdf.Ln(2)
+	r.color(colorTexto)
+}
+
+// fila imprime un par etiqueta: valor
+func (r *renderizador) fila(etiqueta, valor string) {
+	if valor == "" {
+		valor = "-"
+	}
+	r.pdf.SetFont("Helvetica", "B", 10)
+	r.pdf.CellFormat(45, altoLinea, r.tr(etiqueta), "", 0, "L", false, 0, "")
+	r.pdf.SetFont("Helvetica", "", 10)
+	// Ancho 0: el valor ocupa hasta el margen derecho vigente
+	r.pdf.MultiCell(0, altoLinea, r.tr(valor), "", "L", false)
+}
+
+func (r *renderizador) datosBodega(reporte *Reporte) {
+	b := reporte.Bodega
+	r.titulo("Datos de la bodega")
+
+	r.fila("Nombre", b.Nombre)
+	if b.NombreFantasia != "" && b.NombreFantasia != b.Nombre {
+		r.fila("Nombre de fantasía", b.NombreFantasia)
+	}
+	r.fila("Razón social", b.RazonSocial)
+	r.fila("CUIT", domain.FormatearCuit(b.Cuit))
+	r.fila("N° de INV", strconv.Itoa(b.Inv))
+	r.fila("Viñedos INV", strconv.Itoa(b.ViñedosInv))
+	r.fila("Ubicación", b.Ubicacion)
+	r.fila("Contacto", b.ContactoEmail)
+}
+
+func (r *renderizador) resumen(reporte *Reporte) {
+	ev := reporte.Evaluacion
+	res := reporte.Resultado
+	r.titulo("Resultado de la evaluación")
+
+	r.fila("Evaluación N°", strconv.Itoa(ev.IdEvaluacion))
+	r.fila("Segmento", reporte.Segmento)
+	r.fila("Fecha de inicio", formatearFecha(ev.FechaInicio))
+	if ev.FechaCompletado != nil {
+		r.fila("Fecha de finalización", formatearFecha(*ev.FechaCompletado))
+	}
+	if res.Provisorio {
+		r.fila("Estado", "En edición (resultado provisorio)")
+	}
+	if ev.Estado == domain.EstadoAprobada {
+		r.fila("Estado", "Aprobada por auditoría (puntaje con los niveles auditados)")
+	}
+
+	r.pdf.Ln(3)
+	nivel := "Sin nivel asignado"
+	if res.NivelSostenibilidad != nil {
+		nivel = res.NivelSostenibilidad.Nombre
+	}
+
+	// Recuadro destacado con el puntaje y el nivel de sostenibilidad
+	r.relleno(colorVinoSuave)
+	y := r.pdf.GetY()
+	r.pdf.Rect(margen, y, anchoUtil, 20, "F")
+	r.pdf.SetXY(margen+4, y+3)
+	r.pdf.SetFont("Helvetica", "B", 18)
+	r.color(colorVino)
+	r.pdf.CellFormat(70, 9, fmt.Sprintf("%d / %d", res.PuntajeTotal, res.PuntajeMaximo), "", 0, "L", false, 0, "")
+	r.pdf.SetFont("Helvetica", "B", 14)
+	r.pdf.CellFormat(anchoUtil-78, 9, r.tr(nivel), "", 1, "R", false, 0, "")
+	r.pdf.SetX(margen + 4)
+	r.pdf.SetFont("Helvetica", "", 10)
+	r.color(colorTexto)
+	r.pdf.CellFormat(70, 6, r.tr(fmt.Sprintf("%.1f%% del puntaje máximo", res.Porcentaje)), "", 0, "L", false, 0, "")
+	r.pdf.CellFormat(anchoUtil-78, 6, r.tr("Nivel de sostenibilidad"), "", 1, "R", false, 0, "")
+	r.pdf.SetY(y + 24)
+}
+
+// certificado imprime los datos del certificado y un código QR con la dirección de
+// verificación pública
+func (r *renderizador) certificado(reporte *Reporte) {
+	cert := reporte.Certificado
+	if cert == nil {
+		return
+	}
+
+	png, err := qr.Encode(reporte.URLVerificacion, qr.M)
+	if err != nil {
+		r.pdf.SetError(fmt.Errorf("error al generar el código QR: %w", err))
+		return
+	}
+
+	r.titulo("Certificado de sostenibilidad")
+
+	const ladoQR = 32.0
+	if r.pdf.GetY()+ladoQR > 297-20 {
+		r.pdf.AddPage()
+	}
+	y := r.pdf.GetY()
+	r.pdf.RegisterImageOptionsReader("qr-certificado", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png.PNG()))
+	r.pdf.ImageOptions("qr-certificado", margen+anchoUtil-ladoQR, y, ladoQR, ladoQR, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	// Los datos se imprimen a la izquierda del QR
+	r.pdf.SetRightMargin(margen + ladoQR + 4)
+	r.fila("Código", cert.Codigo)
+	r.fila("Nivel certificado", cert.Nivel)
+	r.fila("Fecha de emisión", formatearFecha(cert.FechaEmision))
+	r.fila("Válido hasta", formatearFecha(cert.FechaVencimiento))
+	r.pdf.SetFont("Helvetica", "", 8)
+	r.color(colorGris)
+	r.pdf.MultiCell(0, 4, r.tr("Escanee el código QR o ingrese a "+reporte.URLVerificacion+" para verificar su autenticidad."), "", "L", false)
+	r.color(colorTexto)
+	r.pdf.SetRightMargin(margen)
+
+	if fin := y + ladoQR + 2; r.pdf.GetY() < fin {
+		r.pdf.SetY(fin)
+	}
+}
+
+// capitulos imprime el puntaje de cada capítulo como un gráfico de barras horizontal
+func (r *renderizador) capitulos(reporte *Reporte) {
+	r.titulo("Puntaje por capítulo")
+
+	const (
+		anchoNombre  = 70.0
+		anchoPuntaje = 25.0
+		altoBarra    = 5.0
+	)
+	anchoBarra := anchoUtil - anchoNombre - anchoPuntaje - 4
+
+	for _, capitulo := range reporte.Resultado.Capitulos {
+		nombre := capitulo.Nombre
+		if nombre == "" {
+			nombre = fmt.Sprintf("Capítulo %d", capitulo.NumeroCapitulo)
+		}
+
+		r.pdf.SetFont("Helvetica", "", 9)
+		r.color(colorTexto)
+		y := r.pdf.GetY()
+		r.pdf.CellFormat(anchoNombre, altoLinea, r.tr(recortar(nombre, 42)), "", 0, "L", false, 0, "")
+
+		x := r.pdf.GetX() + 2
+		r.pdf.SetFillColor(230, 230, 230)
+		r.pdf.Rect(x, y+0.5, anchoBarra, altoBarra-1, "F")
+		r.relleno(colorVino)
+		r.pdf.Rect(x, y+0.5, anchoBarra*capitulo.Porcentaje/100, altoBarra-1, "F")
+
+		r.pdf.SetX(x + anchoBarra + 2)
+		r.pdf.CellFormat(anchoPuntaje, altoLinea, fmt.Sprintf("%d/%d (%.0f%%)", capitulo.Puntaje, capitulo.PuntajeMaximo, capitulo.Porcentaje), "", 1, "R", false, 0, "")
+	}
+}
+
+// indicadores imprime, agrupado por capítulo, el nivel elegido y su descripción
+func (r *renderizador) indicadores(reporte *Reporte) {
+	r.titulo("Detalle por indicador (niveles autodeclarados)")
+
+	capituloActual := -1
+	for _, evaluado := range reporte.Indicadores {
+		if evaluado.Capitulo.Numero != capituloActual {
+			capituloActual = evaluado.Capitulo.Numero
+			nombre := evaluado.Capitulo.Nombre
+			if nombre == "" {
+				nombre = fmt.Sprintf("Capítulo %d", capituloActual)
+			}
+			r.pdf.Ln(1)
+			r.pdf.SetFont("Helvetica", "B", 11)
+			r.color(colorVino)
+			r.pdf.MultiCell(anchoUtil, altoLinea+1, r.tr(nombre), "", "L", false)
+			r.color(colorTexto)
+		}
+
+		nivel := "Sin responder"
+		descripcion := ""
+		if evaluado.Nivel != nil {
+			nivel = fmt.Sprintf("Nivel %d", *evaluado.Nivel)
+			descripcion = descripcionNivel(evaluado.Indicador, *evaluado.Nivel)
+		}
+
+		r.pdf.SetFont("Helvetica", "B", 9)
+		r.pdf.CellFormat(anchoUtil-25, altoLinea, r.tr(evaluado.Indicador.Codigo+" "+recortar(evaluado.Indicador.Nombre, 95)), "", 0, "L", false, 0, "")
+		r.pdf.CellFormat(25, altoLinea, r.tr(nivel), "", 1, "R", false, 0, "")
+		if evaluado.NivelAuditado != nil {
+			// Se informa el nivel autodeclarado; el ajuste del auditor se aclara aparte
+			r.pdf.SetFont("Helvetica", "I", 9)
+			r.color(colorVino)
+			r.pdf.CellFormat(anchoUtil, altoLinea-1, r.tr(fmt.Sprintf("Nivel ajustado por el auditor: %d", *evaluado.NivelAuditado)), "", 1, "R", false, 0, "")
+			r.color(colorTexto)
+		}
+		if descripcion != "" {
+			r.pdf.SetFont("Helvetica", "", 9)
+			r.color(colorGris)
+			r.pdf.MultiCell(anchoUtil, altoLinea-1, r.tr(descripcion), "", "L", false)
+			r.color(colorTexto)
+		}
+		r.pdf.Ln(1)
+	}
+}
+
+// mejoras imprime los ítems del plan de mejora
+func (r *renderizador) mejoras(reporte *Reporte) {
+	r.titulo("Plan de mejora")
+
+	if len(reporte.Mejoras) == 0 {
+		r.pdf.SetFont("Helvetica", "", 10)
+		r.pdf.MultiCell(anchoUtil, altoLinea, r.tr("Todos los indicadores alcanzaron el nivel máximo."), "", "L", false)
+		return
+	}
+
+	prioridades := map[int]string{
+		domain.PrioridadAlta:  "Alta",
+		domain.PrioridadMedia: "Media",
+		domain.PrioridadBaja:  "Baja",
+	}
+
+	for i, item := range reporte.Mejoras {
+		r.pdf.SetFont("Helvetica", "B", 9)
+		encabezado := fmt.Sprintf("%d. %s %s (nivel %d a %d)", i+1, item.Codigo, recortar(item.Indicador, 80), item.NivelActual, item.NivelObjetivo)
+		r.pdf.MultiCell(anchoUtil, altoLinea, r.tr(encabezado), "", "L", false)
+
+		r.pdf.SetFont("Helvetica", "", 9)
+		r.pdf.MultiCell(anchoUtil, altoLinea-1, r.tr(item.Accion), "", "L", false)
+
+		detalle := "Prioridad " + prioridades[item.Prioridad]
+		if item.Responsable != nil {
+			detalle += "  |  Responsable: " + *item.Responsable
+		}
+		if item.FechaLimite != nil {
+			detalle += "  |  Fecha límite: " + formatearFecha(*item.FechaLimite)
+		}
+		if estado, ok := estadosItem[item.Estado]; ok {
+			detalle += "  |  " + estado
+		}
+		r.color(colorGris)
+		r.pdf.MultiCell(anchoUtil, altoLinea-1, r.tr(detalle), "", "L", false)
+		r.color(colorTexto)
+		r.pdf.Ln(1.5)
+	}
+}
+
+func descripcionNivel(indicador domain.Indicador, nivel int) string {
+	for _, n := range indicador.Niveles {
+		if n.Nivel == nivel {
+			return n.Descripcion
+		}
+	}
+	return ""
+}
+
+// formatearFecha muestra una fecha RFC3339 o AAAA-MM-DD como DD/MM/AAAA
+func formatearFecha(fecha string) string {
+	for _, formato := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(formato, fecha); err == nil {
+			return t.Format("02/01/2006")
+		}
+	}
+	return fecha
+}
+
+func recortar(texto string, largo int) string {
+	runas := []rune(texto)
+	if len(runas) <= largo {
+		return texto
+	}
+	return string(runas[:largo-1]) + "…"
+}
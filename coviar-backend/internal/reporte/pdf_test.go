@@ -0,0 +1,136 @@
+package reporte
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+)
+
+func entero(n int) *int {
+	return &n
+}
+
+func texto(s string) *string {
+	return &s
+}
+
+// reporteDePrueba arma un informe completo sin acceder a la base de datos
+func reporteDePrueba() *Reporte {
+	capitulo := domain.Capitulo{IdCapitulo: 1, Numero: 1, Nombre: "Gestión del agua y la energía"}
+	vigente := domain.Indicador{
+		IdIndicador: 1,
+		IdCapitulo:  1,
+		Codigo:      "1.1",
+		Nombre:      "Consumo de agua en la bodega",
+		Vigente:     true,
+		Niveles: []domain.NivelIndicador{
+			{Nivel: 2, Descripcion: "Se mide el consumo de agua por visitante y se fijan metas de reducción."},
+			{Nivel: 3, Descripcion: "Se reutiliza el agua tratada en riego y limpieza."},
+		},
+	}
+	// Indicador de una versión retirada del catálogo, sin descripciones de niveles
+	retirado := domain.Indicador{IdIndicador: 2, IdCapitulo: 1, Codigo: "1.2", Nombre: "Indicador retirado", Vigente: false}
+
+	return &Reporte{
+		Bodega: domain.Bodega{
+			IdBodega:       7,
+			Nombre:         "Bodega Ñandú",
+			NombreFantasia: "Ñandú Wines",
+			RazonSocial:    "Ñandú S.A.",
+			Cuit:           30711234562,
+			Inv:            12345,
+			Ubicacion:      "Maipú, Mendoza",
+			ContactoEmail:  "contacto@nandu.com.ar",
+		},
+		Evaluacion: domain.Evaluacion{
+			IdEvaluacion:    42,
+			IdBodega:        7,
+			FechaInicio:     "2026-03-01T10:00:00Z",
+			FechaCompletado: texto("2026-03-15T18:30:00Z"),
+			Estado:          domain.EstadoAprobada,
+		},
+		Segmento: "Bodega turística mediana",
+		Resultado: evaluacion.ResultadoEvaluacion{
+			IdEvaluacion:        42,
+			Estado:              domain.EstadoAprobada,
+			PuntajeTotal:        2,
+			PuntajeMaximo:       6,
+			Porcentaje:          33.3,
+			NivelSostenibilidad: &domain.NivelSostenibilidad{IdNvSos: 1, Nombre: "Nivel mínimo de sostenibilidad"},
+			Capitulos: []domain.PuntajeCapitulo{
+				{NumeroCapitulo: 1, Nombre: capitulo.Nombre, Puntaje: 2, PuntajeMaximo: 6, Porcentaje: 33.3},
+				{NumeroCapitulo: 2, Puntaje: 0, PuntajeMaximo: 0},
+			},
+		},
+		Indicadores: []evaluacion.IndicadorEvaluado{
+			{Capitulo: capitulo, Indicador: vigente, Nivel: entero(2), NivelAuditado: entero(1)},
+			{Capitulo: capitulo, Indicador: retirado, Nivel: entero(3)},
+			{Capitulo: domain.Capitulo{Numero: 2}, Indicador: domain.Indicador{IdIndicador: 3, Codigo: "2.1"}},
+		},
+		Mejoras: []domain.ItemPlan{
+			{
+				Codigo:        "1.1",
+				Indicador:     vigente.Nombre,
+				NivelActual:   2,
+				NivelObjetivo: 3,
+				Accion:        "Se reutiliza el agua tratada en riego y limpieza.",
+				Responsable:   texto("Jefe de mantenimiento"),
+				FechaLimite:   texto("2026-12-31"),
+				Prioridad:     domain.PrioridadAlta,
+				Estado:        domain.ItemEnCurso,
+			},
+		},
+		FechaEmision: time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC),
+		Certificado: &domain.Certificado{
+			Codigo:           "COV-2026-000042",
+			IdEvaluacion:     42,
+			IdBodega:         7,
+			NombreBodega:     "Bodega Ñandú",
+			Nivel:            "Nivel mínimo de sostenibilidad",
+			FechaEmision:     "2026-03-20",
+			FechaVencimiento: "2028-03-20",
+		},
+		URLVerificacion: "https://coviar.example/certificados/COV-2026-000042",
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	tests := []struct {
+		nombre  string
+		reporte func() *Reporte
+	}{
+		{"completo", reporteDePrueba},
+		{"sin certificado ni mejoras", func() *Reporte {
+			r := reporteDePrueba()
+			r.Certificado = nil
+			r.Mejoras = nil
+			return r
+		}},
+		{"sin indicadores ni capítulos", func() *Reporte {
+			r := reporteDePrueba()
+			r.Indicadores = nil
+			r.Resultado.Capitulos = nil
+			r.Resultado.NivelSostenibilidad = nil
+			r.Evaluacion.Estado = domain.EstadoBorrador
+			r.Evaluacion.FechaCompletado = nil
+			r.Resultado.Provisorio = true
+			return r
+		}},
+		{"vacío", func() *Reporte { return &Reporte{} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nombre, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderPDF(&buf, tt.reporte()); err != nil {
+				t.Fatalf("RenderPDF devolvió un error: %v", err)
+			}
+			if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+				t.Fatalf("el resultado no empieza con el encabezado %%PDF-: %q", buf.Bytes()[:min(buf.Len(), 16)])
+			}
+		})
+	}
+}
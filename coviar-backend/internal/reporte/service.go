@@ -0,0 +1,114 @@
+// RUTA: coviar-backend/internal/reporte/service.go
+package reporte
+
+import (
+	"errors"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/bodega"
+	"github.com/carli/coviar-backend/internal/certificado"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+	"github.com/carli/coviar-backend/internal/plan"
+	"github.com/carli/coviar-backend/internal/segmento"
+)
+
+// Reporte reúne todos los datos que se imprimen en el informe de una evaluación.
+// Se arma a partir de la base de datos pero se puede construir a mano para generar
+// el PDF sin conexión.
+type Reporte struct {
+	Bodega       domain.Bodega
+	Evaluacion   domain.Evaluacion
+	Segmento     string
+	Resultado    evaluacion.ResultadoEvaluacion
+	Indicadores  []evaluacion.IndicadorEvaluado
+	Mejoras      []domain.ItemPlan
+	FechaEmision time.Time
+
+	// Certificado emitido para la evaluación (nil si todavía no tiene) y la dirección
+	// donde se verifica, que se imprime como código QR
+	Certificado     *domain.Certificado
+	URLVerificacion string
+}
+
+// Service arma los datos del informe de una evaluación
+type Service struct {
+	evaluaciones *evaluacion.Service
+	bodegas      *bodega.Service
+	segmentos    *segmento.Service
+	planes       *plan.Service
+	certificados *certificado.Service
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(evaluacionService *evaluacion.Service, bodegaService *bodega.Service, segmentoService *segmento.Service, planService *plan.Service, certificadoService *certificado.Service) *Service {
+	return &Service{
+		evaluaciones: evaluacionService,
+		bodegas:      bodegaService,
+		segmentos:    segmentoService,
+		planes:       planService,
+		certificados: certificadoService,
+	}
+}
+
+// Armar reúne los datos del informe de una evaluación. Si la bodega tiene un plan de
+// mejora generado a partir de esta evaluación se usan sus ítems (con las ediciones de
+// la bodega); si no, se incluyen los ítems sugeridos.
+func (s *Service) Armar(idEvaluacion int) (*Reporte, error) {
+	ev, err := s.evaluaciones.GetByID(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	bod, err := s.bodegas.GetByID(ev.IdBodega)
+	if err != nil {
+		return nil, err
+	}
+
+	reporte := &Reporte{
+		Bodega:       *bod,
+		Evaluacion:   *ev,
+		FechaEmision: time.Now(),
+	}
+
+	if seg, err := s.segmentos.GetByID(ev.IdSegmento); err == nil {
+		reporte.Segmento = seg.Nombre
+	} else if !errors.Is(err, segmento.ErrSegmentoNoEncontrado) {
+		return nil, err
+	}
+
+	resultado, err := s.evaluaciones.GetResultado(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+	reporte.Resultado = *resultado
+
+	reporte.Indicadores, err = s.evaluaciones.GetIndicadoresEvaluados(idEvaluacion)
+	if err != nil {
+		return nil, err
+	}
+
+	planBodega, err := s.planes.GetByBodega(ev.IdBodega)
+	if err != nil && !errors.Is(err, plan.ErrPlanNoEncontrado) {
+		return nil, err
+	}
+	if planBodega != nil && planBodega.IdEvaluacion == idEvaluacion {
+		reporte.Mejoras = planBodega.Items
+	} else {
+		reporte.Mejoras, err = s.planes.Sugerir(idEvaluacion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := s.certificados.GetByEvaluacion(idEvaluacion)
+	if err != nil && !errors.Is(err, certificado.ErrCertificadoNoEncontrado) {
+		return nil, err
+	}
+	if cert != nil {
+		reporte.Certificado = cert
+		reporte.URLVerificacion = s.certificados.URLVerificacion(cert.Codigo)
+	}
+
+	return reporte, nil
+}
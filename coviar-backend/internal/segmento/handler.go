@@ -0,0 +1,120 @@
+// RUTA: coviar-backend/internal/segmento/handler.go
+package segmento
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Handler maneja las peticiones HTTP para Segmento
+type Handler struct {
+	service *Service
+}
+
+// NewHandler crea una nueva instancia del handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ListSegmentos maneja GET /api/segmentos
+func (h *Handler) ListSegmentos(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	segmentos, err := h.service.GetAll()
+	if err != nil {
+		log.Printf("Error al obtener segmentos: %v", err)
+		sendError(w, "Error al obtener segmentos", http.StatusInternalServerError)
+		return
+	}
+
+	sendSuccess(w, segmentos)
+}
+
+// GetSegmento maneja GET /api/segmentos/{id} y GET /api/segmentos/{id}/indicadores
+func (h *Handler) GetSegmento(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extraer ID y sub-recurso de la URL
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/segmentos/"), "/")
+	parts := strings.Split(path, "/")
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		sendError(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "indicadores" {
+		indicadores, err := h.service.GetIndicadoresAplicables(id)
+		if err != nil {
+			log.Printf("Error al obtener indicadores del segmento: %v", err)
+			sendLookupError(w, err, "Error al obtener indicadores")
+			return
+		}
+		sendSuccess(w, indicadores)
+		return
+	}
+
+	if len(parts) != 1 {
+		sendError(w, "Ruta no válida", http.StatusNotFound)
+		return
+	}
+
+	segmento, err := h.service.GetByID(id)
+	if err != nil {
+		log.Printf("Error al obtener segmento: %v", err)
+		sendLookupError(w, err, "Error al obtener segmento")
+		return
+	}
+
+	sendSuccess(w, segmento)
+}
+
+// Utilidades para respuestas JSON
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type successResponse struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error:   "error",
+		Message: message,
+	})
+}
+
+func sendSuccess(w http.ResponseWriter, data interface{}) {
+	json.NewEncoder(w).Encode(successResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+func sendLookupError(w http.ResponseWriter, err error, fallback string) {
+	if errors.Is(err, ErrSegmentoNoEncontrado) {
+		sendError(w, "Segmento no encontrado", http.StatusNotFound)
+		return
+	}
+	sendError(w, fallback, http.StatusInternalServerError)
+}
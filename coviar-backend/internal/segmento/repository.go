@@ -0,0 +1,131 @@
+// RUTA: coviar-backend/internal/segmento/repository.go
+package segmento
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/platform/database"
+	"github.com/supabase-community/postgrest-go"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// Repository maneja el acceso a datos de Segmento y sus reglas de aplicabilidad
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindAll obtiene todos los segmentos ordenados por cantidad mínima de turistas
+func (r *Repository) FindAll() ([]domain.Segmento, error) {
+	data, _, err := r.db.From("segmento").
+		Select("*", "", false).
+		Order("min_turistas", &postgrest.OrderOpts{Ascending: true, NullsFirst: true}).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var segmentos []domain.Segmento
+	if err := json.Unmarshal(data, &segmentos); err != nil {
+		return nil, err
+	}
+
+	return segmentos, nil
+}
+
+// FindByID obtiene un segmento por ID
+func (r *Repository) FindByID(id int) (*domain.Segmento, error) {
+	data, _, err := r.db.From("segmento").
+		Select("*", "", false).
+		Eq("idSegmento", fmt.Sprintf("%d", id)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var segmentos []domain.Segmento
+	if err := json.Unmarshal(data, &segmentos); err != nil {
+		return nil, err
+	}
+
+	if len(segmentos) == 0 {
+		return nil, ErrSegmentoNoEncontrado
+	}
+
+	return &segmentos[0], nil
+}
+
+// regla asigna un indicador a un segmento
+type regla struct {
+	IdSegmento  int `json:"idSegmento"`
+	IdIndicador int `json:"idIndicador"`
+}
+
+// FindReglasByIndicadores obtiene las reglas de aplicabilidad de los indicadores dados
+func (r *Repository) FindReglasByIndicadores(idsIndicador []int) ([]regla, error) {
+	if len(idsIndicador) == 0 {
+		return nil, nil
+	}
+
+	data, _, err := r.db.From("segmento_indicador").
+		Select("*", "", false).
+		In("idIndicador", database.ToStrings(idsIndicador)).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var reglas []regla
+	if err := json.Unmarshal(data, &reglas); err != nil {
+		return nil, err
+	}
+
+	return reglas, nil
+}
+
+// CreateReglas crea en bloque reglas de aplicabilidad
+func (r *Repository) CreateReglas(reglas []regla) error {
+	if len(reglas) == 0 {
+		return nil
+	}
+
+	_, _, err := r.db.From("segmento_indicador").
+		Insert(reglas, false, "", "minimal", "").
+		Execute()
+
+	return err
+}
+
+// FindIndicadoresVigentes obtiene los indicadores de la versión publicada del catálogo
+func (r *Repository) FindIndicadoresVigentes() ([]domain.Indicador, error) {
+	data, _, err := r.db.From("indicador").
+		Select("*", "", false).
+		Eq("vigente", "true").
+		Order("codigo", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var indicadores []domain.Indicador
+	if err := json.Unmarshal(data, &indicadores); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(indicadores, func(i, j int) bool {
+		return domain.CompararCodigos(indicadores[i].Codigo, indicadores[j].Codigo) < 0
+	})
+
+	return indicadores, nil
+}
@@ -0,0 +1,126 @@
+// RUTA: coviar-backend/internal/segmento/service.go
+package segmento
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/carli/coviar-backend/internal/domain"
+)
+
+// ErrSegmentoNoEncontrado se devuelve cuando el segmento pedido no existe
+var ErrSegmentoNoEncontrado = errors.New("segmento no encontrado")
+
+// Service contiene la lógica de negocio de Segmento
+type Service struct {
+	repo *Repository
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// GetAll obtiene todos los segmentos
+func (s *Service) GetAll() ([]domain.Segmento, error) {
+	return s.repo.FindAll()
+}
+
+// GetByID obtiene un segmento por ID
+func (s *Service) GetByID(id int) (*domain.Segmento, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+
+	return s.repo.FindByID(id)
+}
+
+// GetByTuristas obtiene el segmento cuyo rango de turistas anuales contiene la cantidad dada
+func (s *Service) GetByTuristas(turistas int) (*domain.Segmento, error) {
+	if turistas < 0 {
+		return nil, fmt.Errorf("la cantidad de turistas no puede ser negativa")
+	}
+
+	segmentos, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range segmentos {
+		if segmentos[i].Contiene(turistas) {
+			return &segmentos[i], nil
+		}
+	}
+
+	return nil, ErrSegmentoNoEncontrado
+}
+
+// GetIndicadoresAplicables obtiene los indicadores vigentes que aplican a un segmento.
+// Un segmento sin reglas cargadas en segmento_indicador evalúa todos los indicadores vigentes.
+func (s *Service) GetIndicadoresAplicables(idSegmento int) ([]domain.Indicador, error) {
+	if _, err := s.GetByID(idSegmento); err != nil {
+		return nil, err
+	}
+
+	vigentes, err := s.repo.FindIndicadoresVigentes()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FiltrarAplicables(idSegmento, vigentes)
+}
+
+// FiltrarAplicables devuelve, de los indicadores dados, los que aplican a un segmento.
+// Un segmento sin reglas para esos indicadores los evalúa a todos.
+func (s *Service) FiltrarAplicables(idSegmento int, indicadores []domain.Indicador) ([]domain.Indicador, error) {
+	ids := make([]int, len(indicadores))
+	for i, indicador := range indicadores {
+		ids[i] = indicador.IdIndicador
+	}
+
+	reglas, err := s.repo.FindReglasByIndicadores(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Indicadores asignados al segmento, y si el segmento tiene alguna regla
+	asignados := make(map[int]bool)
+	for _, regla := range reglas {
+		if regla.IdSegmento == idSegmento {
+			asignados[regla.IdIndicador] = true
+		}
+	}
+	if len(asignados) == 0 {
+		return indicadores, nil
+	}
+
+	var aplicables []domain.Indicador
+	for _, indicador := range indicadores {
+		if asignados[indicador.IdIndicador] {
+			aplicables = append(aplicables, indicador)
+		}
+	}
+
+	return aplicables, nil
+}
+
+// CopiarReglas replica las reglas de aplicabilidad de un conjunto de indicadores en sus
+// copias de una nueva versión del catálogo (mapa ID original -> ID nuevo)
+func (s *Service) CopiarReglas(nuevosIds map[int]int) error {
+	originales := make([]int, 0, len(nuevosIds))
+	for id := range nuevosIds {
+		originales = append(originales, id)
+	}
+
+	reglas, err := s.repo.FindReglasByIndicadores(originales)
+	if err != nil {
+		return err
+	}
+
+	copias := make([]regla, 0, len(reglas))
+	for _, r := range reglas {
+		copias = append(copias, regla{IdSegmento: r.IdSegmento, IdIndicador: nuevosIds[r.IdIndicador]})
+	}
+
+	return s.repo.CreateReglas(copias)
+}
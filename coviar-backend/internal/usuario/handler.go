@@ -71,6 +71,36 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Crear maneja POST /api/usuarios - Alta de un usuario con cualquier rol (solo admin).
+// A diferencia de Register no inicia la sesión del usuario creado.
+func (h *Handler) Crear(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodPost {
+		sendError(w, "Método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var dto domain.UsuarioDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		sendError(w, "Datos inválidos", http.StatusBadRequest)
+		return
+	}
+
+	usuario, err := h.service.CreateConRol(&dto)
+	if err != nil {
+		log.Printf("Error al crear usuario: %v", err)
+		sendError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	sendSuccess(w, map[string]interface{}{
+		"usuario": usuario.ToPublic(),
+		"message": "Usuario creado exitosamente",
+	})
+}
+
 // Login maneja POST /api/auth/login - Verificar credenciales del usuario
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -21,8 +21,23 @@ func NewService(repo *Repository) *Service {
 	return &Service{repo: repo}
 }
 
-// Create crea un nuevo usuario con validaciones
+// Create registra un nuevo usuario con rol bodega. El rol que venga en el DTO se ignora:
+// solo un administrador puede dar de alta administradores o auditores (CreateConRol).
 func (s *Service) Create(dto *domain.UsuarioDTO) (*domain.Usuario, error) {
+	return s.crear(dto, "bodega")
+}
+
+// CreateConRol crea un usuario con el rol indicado en el DTO (admin, bodega o auditor)
+func (s *Service) CreateConRol(dto *domain.UsuarioDTO) (*domain.Usuario, error) {
+	validRoles := map[string]bool{"admin": true, "bodega": true, "auditor": true}
+	if !validRoles[dto.Rol] {
+		return nil, fmt.Errorf("rol inválido: debe ser admin, bodega o auditor")
+	}
+	return s.crear(dto, dto.Rol)
+}
+
+// crear crea un nuevo usuario con validaciones
+func (s *Service) crear(dto *domain.UsuarioDTO, rol string) (*domain.Usuario, error) {
 	// Validar email
 	email := strings.TrimSpace(dto.Email)
 	if !isValidEmail(email) {
@@ -49,12 +64,6 @@ func (s *Service) Create(dto *domain.UsuarioDTO) (*domain.Usuario, error) {
 		return nil, fmt.Errorf("el apellido es requerido")
 	}
 
-	// Validar rol
-	validRoles := map[string]bool{"admin": true, "bodega": true, "auditor": true}
-	if !validRoles[dto.Rol] {
-		dto.Rol = "bodega" // Rol por defecto
-	}
-
 	// Hash de la contraseña
 	hashedPassword, err := hashPassword(password)
 	if err != nil {
@@ -67,7 +76,7 @@ func (s *Service) Create(dto *domain.UsuarioDTO) (*domain.Usuario, error) {
 		PasswordHash: hashedPassword,
 		Nombre:       strings.TrimSpace(dto.Nombre),
 		Apellido:     strings.TrimSpace(dto.Apellido),
-		Rol:          dto.Rol,
+		Rol:          rol,
 		Activo:       true,
 	}
 
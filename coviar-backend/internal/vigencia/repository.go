@@ -0,0 +1,85 @@
+// RUTA: coviar-backend/internal/vigencia/repository.go
+package vigencia
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/domain"
+	supa "github.com/supabase-community/supabase-go"
+)
+
+// Repository maneja el acceso a datos de RecordatorioVencimiento
+type Repository struct {
+	db *supa.Client
+}
+
+// NewRepository crea una nueva instancia del repositorio
+func NewRepository(db *supa.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindByEvaluaciones obtiene los recordatorios ya enviados para un conjunto de evaluaciones
+func (r *Repository) FindByEvaluaciones(idsEvaluacion []int) ([]domain.RecordatorioVencimiento, error) {
+	if len(idsEvaluacion) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(idsEvaluacion))
+	for i, id := range idsEvaluacion {
+		ids[i] = fmt.Sprintf("%d", id)
+	}
+
+	data, _, err := r.db.From("recordatorio_vencimiento").
+		Select("*", "", false).
+		In("idEvaluacion", ids).
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var recordatorios []domain.RecordatorioVencimiento
+	if err := json.Unmarshal(data, &recordatorios); err != nil {
+		return nil, err
+	}
+
+	return recordatorios, nil
+}
+
+// Create registra un recordatorio antes de enviarlo. Devuelve false si ya estaba
+// registrado (lo envió otra ejecución), gracias a la restricción UNIQUE ("idEvaluacion", dias).
+func (r *Repository) Create(recordatorio *domain.RecordatorioVencimiento) (bool, error) {
+	recordatorioMap := map[string]interface{}{
+		"idEvaluacion": recordatorio.IdEvaluacion,
+		"dias":         recordatorio.Dias,
+		"destinatario": recordatorio.Destinatario,
+		"fecha_envio":  time.Now().Format(time.RFC3339),
+	}
+
+	_, _, err := r.db.From("recordatorio_vencimiento").
+		Insert(recordatorioMap, false, "", "minimal", "").
+		Execute()
+
+	if err != nil {
+		if strings.Contains(err.Error(), "23505") || strings.Contains(err.Error(), "duplicate key") {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete elimina el registro de un recordatorio que no se pudo enviar, para reintentarlo
+func (r *Repository) Delete(idEvaluacion int, dias int) error {
+	_, _, err := r.db.From("recordatorio_vencimiento").
+		Delete("minimal", "").
+		Eq("idEvaluacion", fmt.Sprintf("%d", idEvaluacion)).
+		Eq("dias", fmt.Sprintf("%d", dias)).
+		Execute()
+
+	return err
+}
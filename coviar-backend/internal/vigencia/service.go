@@ -0,0 +1,189 @@
+// RUTA: coviar-backend/internal/vigencia/service.go
+package vigencia
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"math"
+	"time"
+
+	"github.com/carli/coviar-backend/internal/bodega"
+	"github.com/carli/coviar-backend/internal/domain"
+	"github.com/carli/coviar-backend/internal/evaluacion"
+	"github.com/carli/coviar-backend/internal/platform/email"
+)
+
+// DiasRecordatorio son las anticipaciones, de mayor a menor, con las que se avisa a la
+// bodega que su evaluación aprobada está por vencer
+var DiasRecordatorio = []int{60, 30, 7}
+
+// Service vence las evaluaciones aprobadas y envía los recordatorios de renovación
+type Service struct {
+	repo         *Repository
+	evaluaciones *evaluacion.Service
+	bodegas      *bodega.Service
+	mailer       email.Mailer
+	frontendURL  string
+}
+
+// NewService crea una nueva instancia del servicio
+func NewService(repo *Repository, evaluacionService *evaluacion.Service, bodegaService *bodega.Service, mailer email.Mailer, frontendURL string) *Service {
+	return &Service{
+		repo:         repo,
+		evaluaciones: evaluacionService,
+		bodegas:      bodegaService,
+		mailer:       mailer,
+		frontendURL:  frontendURL,
+	}
+}
+
+// Programar ejecuta el proceso al iniciar y luego cada intervalo. Bloquea: se llama en
+// una goroutine.
+func (s *Service) Programar(intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Ejecutar(time.Now()); err != nil {
+			log.Printf("Error al procesar vencimientos de evaluaciones: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// Ejecutar marca como vencidas las evaluaciones cuyo plazo terminó y envía los
+// recordatorios pendientes. Ejecutarlo más de una vez el mismo día no repite avisos.
+func (s *Service) Ejecutar(ahora time.Time) error {
+	vencidas, err := s.evaluaciones.VencerEvaluaciones(ahora)
+	if err != nil {
+		return fmt.Errorf("error al vencer evaluaciones: %w", err)
+	}
+	if len(vencidas) > 0 {
+		log.Printf("⏰ %d evaluaciones vencidas", len(vencidas))
+	}
+
+	return s.enviarRecordatorios(ahora)
+}
+
+// enviarRecordatorios avisa por cada evaluación el umbral más cercano que ya alcanzó, si
+// no se envió antes ese aviso u otro más urgente. Así, si el proceso no corrió algún día,
+// la bodega recibe un solo correo y no todos los atrasados.
+func (s *Service) enviarRecordatorios(ahora time.Time) error {
+	porVencer, err := s.evaluaciones.GetPorVencer(ahora.AddDate(0, 0, DiasRecordatorio[0]))
+	if err != nil {
+		return err
+	}
+	if len(porVencer) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(porVencer))
+	for i, ev := range porVencer {
+		ids[i] = ev.IdEvaluacion
+	}
+
+	enviados, err := s.repo.FindByEvaluaciones(ids)
+	if err != nil {
+		return err
+	}
+
+	// Aviso más urgente ya enviado por evaluación
+	ultimoAviso := make(map[int]int)
+	for _, recordatorio := range enviados {
+		if dias, ok := ultimoAviso[recordatorio.IdEvaluacion]; !ok || recordatorio.Dias < dias {
+			ultimoAviso[recordatorio.IdEvaluacion] = recordatorio.Dias
+		}
+	}
+
+	for i := range porVencer {
+		ev := &porVencer[i]
+		if ev.FechaVencimiento == nil {
+			continue
+		}
+
+		vencimiento, err := time.Parse(time.RFC3339, *ev.FechaVencimiento)
+		if err != nil {
+			log.Printf("Fecha de vencimiento inválida en la evaluación %d: %v", ev.IdEvaluacion, err)
+			continue
+		}
+
+		restantes := int(math.Ceil(vencimiento.Sub(ahora).Hours() / 24))
+		umbral := umbralAlcanzado(restantes)
+		if umbral == 0 {
+			continue
+		}
+		if dias, ok := ultimoAviso[ev.IdEvaluacion]; ok && dias <= umbral {
+			continue
+		}
+
+		if err := s.recordar(ev, umbral, restantes, vencimiento); err != nil {
+			log.Printf("Error al enviar recordatorio de la evaluación %d: %v", ev.IdEvaluacion, err)
+		}
+	}
+
+	return nil
+}
+
+// recordar registra el aviso y envía el correo a la bodega. El aviso se registra
+// primero: si otra ejecución ya lo registró no se vuelve a enviar, y si el envío falla se
+// borra para reintentarlo en la próxima.
+func (s *Service) recordar(ev *domain.Evaluacion, umbral, restantes int, vencimiento time.Time) error {
+	bod, err := s.bodegas.GetByID(ev.IdBodega)
+	if err != nil {
+		return err
+	}
+	if bod.ContactoEmail == "" {
+		return fmt.Errorf("la bodega %d no tiene email de contacto", bod.IdBodega)
+	}
+
+	registrado, err := s.repo.Create(&domain.RecordatorioVencimiento{
+		IdEvaluacion: ev.IdEvaluacion,
+		Dias:         umbral,
+		Destinatario: bod.ContactoEmail,
+	})
+	if err != nil || !registrado {
+		return err
+	}
+
+	asunto := fmt.Sprintf("Su evaluación de sostenibilidad vence en %d días", restantes)
+	if err := s.mailer.Enviar(bod.ContactoEmail, asunto, s.cuerpoRecordatorio(bod, restantes, vencimiento)); err != nil {
+		if errBorrado := s.repo.Delete(ev.IdEvaluacion, umbral); errBorrado != nil {
+			log.Printf("Error al descartar el recordatorio no enviado de la evaluación %d: %v", ev.IdEvaluacion, errBorrado)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) cuerpoRecordatorio(bod *domain.Bodega, restantes int, vencimiento time.Time) string {
+	url := s.frontendURL + "/dashboard/autoevaluacion"
+
+	return fmt.Sprintf(`
+		<!DOCTYPE html>
+		<html>
+		<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+			<div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+				<h2>Renovación de la evaluación de sostenibilidad</h2>
+				<p>La evaluación aprobada de <strong>%s</strong> vence el %s (en %d días).</p>
+				<p>Para mantener su nivel de sostenibilidad, inicie la renovación: el nuevo borrador
+				se precarga con las respuestas de la evaluación anterior.</p>
+				<p><a href="%s">%s</a></p>
+			</div>
+		</body>
+		</html>
+	`, html.EscapeString(bod.Nombre), vencimiento.Format("02/01/2006"), restantes, url, url)
+}
+
+// umbralAlcanzado devuelve el menor umbral de aviso que cubre los días restantes, o 0
+// si todavía no corresponde avisar
+func umbralAlcanzado(restantes int) int {
+	umbral := 0
+	for _, dias := range DiasRecordatorio {
+		if restantes <= dias {
+			umbral = dias
+		}
+	}
+	return umbral
+}
@@ -0,0 +1,11 @@
+-- RUTA: coviar-backend/scripts/001_create_respuesta.sql
+-- Respuestas por indicador de cada evaluación (nivel 0 a 3)
+CREATE TABLE IF NOT EXISTS public.respuesta (
+  "idRespuesta" SERIAL PRIMARY KEY,
+  "idEvaluacion" INTEGER NOT NULL REFERENCES public.evaluacion("idEvaluacion") ON DELETE CASCADE,
+  "idIndicador" INTEGER NOT NULL REFERENCES public.indicador("idIndicador"),
+  nivel INTEGER NOT NULL CHECK (nivel >= 0 AND nivel <= 3),
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW(),
+  UNIQUE ("idEvaluacion", "idIndicador")
+);
@@ -0,0 +1,18 @@
+-- RUTA: coviar-backend/scripts/002_create_nivel_sostenibilidad.sql
+-- Rangos de puntaje que definen cada nivel de sostenibilidad
+CREATE TABLE IF NOT EXISTS public.nivel_sostenibilidad (
+  "idNvSos" SERIAL PRIMARY KEY,
+  nombre TEXT NOT NULL,
+  puntaje_minimo INTEGER NOT NULL,
+  puntaje_maximo INTEGER NOT NULL,
+  CHECK (puntaje_minimo <= puntaje_maximo)
+);
+
+-- Valores iniciales (los mismos que usaba el dashboard del frontend)
+INSERT INTO public.nivel_sostenibilidad (nombre, puntaje_minimo, puntaje_maximo)
+SELECT * FROM (VALUES
+  ('Nivel mínimo', 42, 93),
+  ('Nivel medio', 94, 112),
+  ('Nivel alto', 113, 126)
+) AS v(nombre, puntaje_minimo, puntaje_maximo)
+WHERE NOT EXISTS (SELECT 1 FROM public.nivel_sostenibilidad);
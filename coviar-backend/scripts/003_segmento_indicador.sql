@@ -0,0 +1,16 @@
+-- RUTA: coviar-backend/scripts/003_segmento_indicador.sql
+-- Reglas de aplicabilidad: qué indicadores se evalúan en cada segmento.
+-- Un segmento sin filas en esta tabla evalúa todos los indicadores vigentes.
+CREATE TABLE IF NOT EXISTS public.segmento_indicador (
+  "idSegmento" INTEGER NOT NULL REFERENCES public.segmento("idSegmento") ON DELETE CASCADE,
+  "idIndicador" INTEGER NOT NULL REFERENCES public.indicador("idIndicador") ON DELETE CASCADE,
+  PRIMARY KEY ("idSegmento", "idIndicador")
+);
+
+-- Umbrales propios por segmento (NULL = umbral general)
+ALTER TABLE public.nivel_sostenibilidad
+ADD COLUMN IF NOT EXISTS "idSegmento" INTEGER REFERENCES public.segmento("idSegmento") ON DELETE CASCADE;
+
+-- Puntaje máximo posible según los indicadores aplicables al segmento
+ALTER TABLE public.evaluacion
+ADD COLUMN IF NOT EXISTS puntaje_maximo INTEGER;
@@ -0,0 +1,37 @@
+-- RUTA: coviar-backend/scripts/004_catalogo_versionado.sql
+-- Catálogo versionado: cada versión agrupa capítulos, indicadores y descripciones de nivel.
+-- Las evaluaciones quedan asociadas a la versión con la que se iniciaron.
+CREATE TABLE IF NOT EXISTS public.version_catalogo (
+  "idVersion" SERIAL PRIMARY KEY,
+  nombre TEXT NOT NULL,
+  estado TEXT NOT NULL DEFAULT 'borrador' CHECK (estado IN ('borrador', 'publicada', 'retirada')),
+  fecha_creacion TIMESTAMPTZ DEFAULT NOW(),
+  fecha_publicacion TIMESTAMPTZ,
+  fecha_retiro TIMESTAMPTZ
+);
+
+-- Solo puede haber una versión publicada a la vez
+CREATE UNIQUE INDEX IF NOT EXISTS version_catalogo_una_publicada
+  ON public.version_catalogo (estado) WHERE estado = 'publicada';
+
+CREATE TABLE IF NOT EXISTS public.capitulo (
+  "idCapitulo" SERIAL PRIMARY KEY,
+  "idVersion" INTEGER NOT NULL REFERENCES public.version_catalogo("idVersion") ON DELETE CASCADE,
+  numero INTEGER NOT NULL,
+  nombre TEXT NOT NULL,
+  UNIQUE ("idVersion", numero)
+);
+
+ALTER TABLE public.indicador
+ADD COLUMN IF NOT EXISTS "idCapitulo" INTEGER REFERENCES public.capitulo("idCapitulo") ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS public.nivel_indicador (
+  "idNivelIndicador" SERIAL PRIMARY KEY,
+  "idIndicador" INTEGER NOT NULL REFERENCES public.indicador("idIndicador") ON DELETE CASCADE,
+  nivel INTEGER NOT NULL CHECK (nivel >= 0 AND nivel <= 3),
+  descripcion TEXT NOT NULL,
+  UNIQUE ("idIndicador", nivel)
+);
+
+ALTER TABLE public.evaluacion
+ADD COLUMN IF NOT EXISTS "idVersion" INTEGER REFERENCES public.version_catalogo("idVersion");
@@ -0,0 +1,18 @@
+-- RUTA: coviar-backend/scripts/005_create_evidencia.sql
+-- Archivos de evidencia (PDF o imágenes) adjuntos a la respuesta de un indicador.
+-- El archivo se guarda en el almacenamiento configurado; aquí solo se registra su ubicación.
+CREATE TABLE IF NOT EXISTS public.evidencia (
+  "idEvidencia" SERIAL PRIMARY KEY,
+  "idRespuesta" INTEGER NOT NULL REFERENCES public.respuesta("idRespuesta") ON DELETE CASCADE,
+  "idEvaluacion" INTEGER NOT NULL REFERENCES public.evaluacion("idEvaluacion") ON DELETE CASCADE,
+  "idIndicador" INTEGER NOT NULL REFERENCES public.indicador("idIndicador"),
+  nombre_archivo TEXT NOT NULL,
+  tipo_mime TEXT NOT NULL CHECK (tipo_mime IN ('application/pdf', 'image/jpeg', 'image/png', 'image/webp')),
+  tamano BIGINT NOT NULL CHECK (tamano > 0),
+  ruta TEXT NOT NULL UNIQUE,
+  "idUsuario" INTEGER REFERENCES public.usuario("idUsuario"),
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS evidencia_evaluacion_indicador
+  ON public.evidencia ("idEvaluacion", "idIndicador");
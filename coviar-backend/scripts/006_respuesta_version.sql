@@ -0,0 +1,5 @@
+-- RUTA: coviar-backend/scripts/006_respuesta_version.sql
+-- Control de concurrencia optimista: cada guardado de una respuesta incrementa su versión
+-- y solo se acepta si el cliente trabajó sobre la versión vigente.
+ALTER TABLE public.respuesta
+ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1 CHECK (version >= 1);
@@ -0,0 +1,12 @@
+-- RUTA: coviar-backend/scripts/007_puntaje_capitulo.sql
+-- Puntaje de cada capítulo, calculado y guardado al finalizar una evaluación
+CREATE TABLE IF NOT EXISTS public.puntaje_capitulo (
+  "idPuntajeCapitulo" SERIAL PRIMARY KEY,
+  "idEvaluacion" INTEGER NOT NULL REFERENCES public.evaluacion("idEvaluacion") ON DELETE CASCADE,
+  numero_capitulo INTEGER NOT NULL,
+  nombre TEXT NOT NULL DEFAULT '',
+  puntaje INTEGER NOT NULL CHECK (puntaje >= 0),
+  puntaje_maximo INTEGER NOT NULL CHECK (puntaje_maximo >= 0),
+  porcentaje NUMERIC(5, 1) NOT NULL,
+  UNIQUE ("idEvaluacion", numero_capitulo)
+);
@@ -0,0 +1,27 @@
+-- RUTA: coviar-backend/scripts/008_plan_mejora.sql
+-- Plan de mejora por bodega, generado a partir de los indicadores de su última evaluación
+-- que quedaron por debajo del nivel máximo. Los ítems son editables por la bodega.
+CREATE TABLE IF NOT EXISTS public.plan_mejora (
+  "idPlan" SERIAL PRIMARY KEY,
+  "idBodega" INTEGER NOT NULL UNIQUE REFERENCES public.bodega("idBodega") ON DELETE CASCADE,
+  "idEvaluacion" INTEGER NOT NULL REFERENCES public.evaluacion("idEvaluacion"),
+  fecha_creacion TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS public.item_plan (
+  "idItem" SERIAL PRIMARY KEY,
+  "idPlan" INTEGER NOT NULL REFERENCES public.plan_mejora("idPlan") ON DELETE CASCADE,
+  "idIndicador" INTEGER NOT NULL REFERENCES public.indicador("idIndicador"),
+  codigo TEXT NOT NULL,
+  indicador TEXT NOT NULL,
+  nivel_actual INTEGER NOT NULL CHECK (nivel_actual >= 0 AND nivel_actual <= 3),
+  nivel_objetivo INTEGER NOT NULL CHECK (nivel_objetivo >= 1 AND nivel_objetivo <= 3),
+  accion TEXT NOT NULL,
+  responsable TEXT,
+  fecha_limite DATE,
+  prioridad INTEGER NOT NULL DEFAULT 2 CHECK (prioridad >= 1 AND prioridad <= 3),
+  orden INTEGER NOT NULL DEFAULT 0,
+  estado TEXT NOT NULL DEFAULT 'pendiente' CHECK (estado IN ('pendiente', 'en_curso', 'completado')),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
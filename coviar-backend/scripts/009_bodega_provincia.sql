@@ -0,0 +1,7 @@
+-- RUTA: coviar-backend/scripts/009_bodega_provincia.sql
+-- Provincia de la bodega, usada para filtrar exportaciones y estadísticas
+ALTER TABLE public.bodega
+ADD COLUMN IF NOT EXISTS provincia TEXT;
+
+CREATE INDEX IF NOT EXISTS bodega_provincia ON public.bodega (provincia);
+CREATE INDEX IF NOT EXISTS evaluacion_fecha_inicio ON public.evaluacion (fecha_inicio);
@@ -0,0 +1,17 @@
+-- RUTA: coviar-backend/scripts/010_certificado.sql
+-- Certificados de sostenibilidad firmados por el servidor (Ed25519). Se guardan los
+-- datos tal como se firmaron para poder verificarlos públicamente por su código.
+CREATE TABLE IF NOT EXISTS public.certificado (
+  "idCertificado" SERIAL PRIMARY KEY,
+  codigo TEXT NOT NULL UNIQUE,
+  "idEvaluacion" INTEGER NOT NULL UNIQUE REFERENCES public.evaluacion("idEvaluacion"),
+  "idBodega" INTEGER NOT NULL REFERENCES public.bodega("idBodega"),
+  nombre_bodega TEXT NOT NULL,
+  nivel TEXT NOT NULL,
+  fecha_emision DATE NOT NULL,
+  fecha_vencimiento DATE NOT NULL,
+  firma TEXT NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_certificado_bodega ON public.certificado("idBodega");
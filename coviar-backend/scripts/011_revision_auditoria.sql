@@ -0,0 +1,20 @@
+-- RUTA: coviar-backend/scripts/011_revision_auditoria.sql
+-- Revisión de evaluaciones por auditores. El auditor acepta o ajusta cada respuesta
+-- (el nivel autodeclarado se conserva) y cierra la revisión aprobando o rechazando.
+ALTER TABLE public.evaluacion
+ADD COLUMN IF NOT EXISTS "idAuditor" INTEGER REFERENCES public.usuario("idUsuario"),
+ADD COLUMN IF NOT EXISTS fecha_revision TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS comentario_revision TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_evaluacion_auditor ON public.evaluacion("idAuditor");
+
+ALTER TABLE public.respuesta
+ADD COLUMN IF NOT EXISTS veredicto TEXT CHECK (veredicto IN ('aceptado', 'ajustado')),
+ADD COLUMN IF NOT EXISTS nivel_auditado INTEGER CHECK (nivel_auditado >= 0 AND nivel_auditado <= 3),
+ADD COLUMN IF NOT EXISTS comentario_auditor TEXT,
+ADD COLUMN IF NOT EXISTS fecha_auditoria TIMESTAMPTZ;
+
+ALTER TABLE public.respuesta DROP CONSTRAINT IF EXISTS respuesta_ajuste_con_comentario;
+ALTER TABLE public.respuesta
+ADD CONSTRAINT respuesta_ajuste_con_comentario
+  CHECK (veredicto IS DISTINCT FROM 'ajustado' OR (nivel_auditado IS NOT NULL AND comentario_auditor IS NOT NULL));
@@ -0,0 +1,32 @@
+-- RUTA: coviar-backend/scripts/012_estado_evaluacion.sql
+-- Máquina de estados de la evaluación:
+--   borrador -> enviada -> en_revision -> aprobada | rechazada, rechazada -> reabierta -> enviada
+-- Cada cambio de estado queda registrado con la fecha y el usuario que lo hizo.
+
+-- Las evaluaciones finalizadas antes de la revisión por auditores pasan a enviadas
+UPDATE public.evaluacion SET estado = 'enviada' WHERE estado = 'completada';
+
+-- 014 amplía la restricción con el estado vencida: si ya existe no se reemplaza, para que
+-- volver a ejecutar este script no la achique
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'evaluacion_estado_valido') THEN
+    ALTER TABLE public.evaluacion
+    ADD CONSTRAINT evaluacion_estado_valido
+      CHECK (estado IN ('borrador', 'enviada', 'en_revision', 'aprobada', 'rechazada', 'reabierta'));
+  END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS public.transicion_evaluacion (
+  "idTransicion" SERIAL PRIMARY KEY,
+  "idEvaluacion" INTEGER NOT NULL REFERENCES public.evaluacion("idEvaluacion") ON DELETE CASCADE,
+  estado_anterior TEXT NOT NULL,
+  estado_nuevo TEXT NOT NULL,
+  "idUsuario" INTEGER NOT NULL REFERENCES public.usuario("idUsuario"),
+  rol TEXT NOT NULL,
+  comentario TEXT,
+  fecha TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_transicion_evaluacion
+  ON public.transicion_evaluacion ("idEvaluacion", fecha);
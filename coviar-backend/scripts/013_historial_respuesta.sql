@@ -0,0 +1,33 @@
+-- RUTA: coviar-backend/scripts/013_historial_respuesta.sql
+-- Historial inmutable de los cambios en las respuestas: cada vez que la bodega cambia
+-- un nivel o el auditor lo acepta o ajusta se agrega una fila. Sirve de respaldo
+-- cuando se impugna una decisión de certificación.
+CREATE TABLE IF NOT EXISTS public.historial_respuesta (
+  "idHistorial" BIGSERIAL PRIMARY KEY,
+  "idEvaluacion" INTEGER NOT NULL REFERENCES public.evaluacion("idEvaluacion"),
+  "idIndicador" INTEGER NOT NULL REFERENCES public.indicador("idIndicador"),
+  tipo TEXT NOT NULL CHECK (tipo IN ('autoevaluacion', 'auditoria')),
+  nivel_anterior INTEGER CHECK (nivel_anterior >= 0 AND nivel_anterior <= 3),
+  nivel_nuevo INTEGER NOT NULL CHECK (nivel_nuevo >= 0 AND nivel_nuevo <= 3),
+  veredicto TEXT CHECK (veredicto IN ('aceptado', 'ajustado')),
+  "idUsuario" INTEGER NOT NULL REFERENCES public.usuario("idUsuario"),
+  rol TEXT NOT NULL,
+  comentario TEXT,
+  fecha TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_historial_respuesta
+  ON public.historial_respuesta ("idEvaluacion", "idIndicador", fecha);
+
+-- Solo se admiten inserciones
+CREATE OR REPLACE FUNCTION public.historial_respuesta_inmutable()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'historial_respuesta solo admite inserciones';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS historial_respuesta_inmutable ON public.historial_respuesta;
+CREATE TRIGGER historial_respuesta_inmutable
+  BEFORE UPDATE OR DELETE ON public.historial_respuesta
+  FOR EACH ROW EXECUTE FUNCTION public.historial_respuesta_inmutable();
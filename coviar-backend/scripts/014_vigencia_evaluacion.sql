@@ -0,0 +1,40 @@
+-- RUTA: coviar-backend/scripts/014_vigencia_evaluacion.sql
+-- Vigencia de las evaluaciones aprobadas: cada versión del catálogo define cuántos meses
+-- vale una evaluación desde su envío. Al vencer pasa al estado 'vencida' (lo hace el
+-- proceso programado, sin usuario) y la bodega puede renovarla con un borrador precargado.
+
+ALTER TABLE public.version_catalogo
+ADD COLUMN IF NOT EXISTS meses_vigencia INTEGER NOT NULL DEFAULT 12 CHECK (meses_vigencia > 0);
+
+ALTER TABLE public.evaluacion
+ADD COLUMN IF NOT EXISTS fecha_vencimiento TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS "idEvaluacionAnterior" INTEGER REFERENCES public.evaluacion("idEvaluacion") ON DELETE SET NULL;
+
+-- Las evaluaciones ya aprobadas vencen según la versión con la que se iniciaron
+UPDATE public.evaluacion e
+SET fecha_vencimiento = e.fecha_completado + make_interval(months => COALESCE(
+  (SELECT v.meses_vigencia FROM public.version_catalogo v WHERE v."idVersion" = e."idVersion"), 12))
+WHERE e.estado = 'aprobada'
+  AND e.fecha_vencimiento IS NULL
+  AND e.fecha_completado IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_evaluacion_vencimiento
+  ON public.evaluacion (estado, fecha_vencimiento);
+
+ALTER TABLE public.evaluacion DROP CONSTRAINT IF EXISTS evaluacion_estado_valido;
+ALTER TABLE public.evaluacion
+ADD CONSTRAINT evaluacion_estado_valido
+  CHECK (estado IN ('borrador', 'enviada', 'en_revision', 'aprobada', 'rechazada', 'reabierta', 'vencida'));
+
+-- Las transiciones automáticas (aprobada -> vencida) no tienen usuario
+ALTER TABLE public.transicion_evaluacion ALTER COLUMN "idUsuario" DROP NOT NULL;
+
+-- Recordatorios de vencimiento enviados (60, 30 y 7 días antes), para no repetirlos
+CREATE TABLE IF NOT EXISTS public.recordatorio_vencimiento (
+  "idRecordatorio" SERIAL PRIMARY KEY,
+  "idEvaluacion" INTEGER NOT NULL REFERENCES public.evaluacion("idEvaluacion") ON DELETE CASCADE,
+  dias INTEGER NOT NULL,
+  destinatario TEXT NOT NULL,
+  fecha_envio TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE ("idEvaluacion", dias)
+);
@@ -0,0 +1,4 @@
+-- RUTA: coviar-backend/scripts/015_directorio_publico.sql
+-- Directorio público de bodegas certificadas: cada bodega puede excluirse
+ALTER TABLE public.bodega
+ADD COLUMN IF NOT EXISTS excluir_directorio BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,41 @@
+-- RUTA: coviar-backend/scripts/016_membresia_bodega.sql
+-- Membresía de usuarios en bodegas: cada usuario con rol bodega accede solo a las
+-- bodegas de las que es miembro, como propietario, editor o lector. Los propietarios
+-- invitan a otros usuarios por email con un token de un solo uso.
+
+CREATE TABLE IF NOT EXISTS public.miembro_bodega (
+  "idMiembro" SERIAL PRIMARY KEY,
+  "idUsuario" INTEGER NOT NULL REFERENCES public.usuario("idUsuario") ON DELETE CASCADE,
+  "idBodega" INTEGER NOT NULL REFERENCES public.bodega("idBodega") ON DELETE CASCADE,
+  rol TEXT NOT NULL CHECK (rol IN ('propietario', 'editor', 'lector')),
+  fecha_alta TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE ("idUsuario", "idBodega")
+);
+
+CREATE INDEX IF NOT EXISTS idx_miembro_bodega_bodega
+  ON public.miembro_bodega ("idBodega");
+
+-- Solo se guarda el hash SHA-256 del token; el token viaja únicamente en el email
+CREATE TABLE IF NOT EXISTS public.invitacion_bodega (
+  "idInvitacion" SERIAL PRIMARY KEY,
+  "idBodega" INTEGER NOT NULL REFERENCES public.bodega("idBodega") ON DELETE CASCADE,
+  email TEXT NOT NULL,
+  rol TEXT NOT NULL CHECK (rol IN ('propietario', 'editor', 'lector')),
+  token_hash TEXT NOT NULL UNIQUE,
+  "idUsuarioInvitante" INTEGER REFERENCES public.usuario("idUsuario") ON DELETE SET NULL,
+  fecha_creacion TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  fecha_expiracion TIMESTAMPTZ NOT NULL,
+  fecha_aceptacion TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitacion_bodega_bodega
+  ON public.invitacion_bodega ("idBodega");
+
+-- Las bodegas existentes quedan a cargo del usuario con rol bodega cuyo email coincide
+-- con el email de contacto
+INSERT INTO public.miembro_bodega ("idUsuario", "idBodega", rol)
+SELECT u."idUsuario", b."idBodega", 'propietario'
+FROM public.bodega b
+JOIN public.usuario u ON lower(u.email) = lower(b.contacto_email)
+WHERE u.rol = 'bodega'
+ON CONFLICT ("idUsuario", "idBodega") DO NOTHING;
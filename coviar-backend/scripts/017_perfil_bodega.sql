@@ -0,0 +1,9 @@
+-- RUTA: coviar-backend/scripts/017_perfil_bodega.sql
+-- Perfil editable de la bodega (página de configuración): teléfono de contacto,
+-- departamento, distrito y actividades, con la fecha de la última modificación
+ALTER TABLE public.bodega
+ADD COLUMN IF NOT EXISTS contacto_telefono TEXT,
+ADD COLUMN IF NOT EXISTS departamento TEXT,
+ADD COLUMN IF NOT EXISTS distrito TEXT,
+ADD COLUMN IF NOT EXISTS actividades TEXT[] NOT NULL DEFAULT '{}',
+ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
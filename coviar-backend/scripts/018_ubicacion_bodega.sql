@@ -0,0 +1,14 @@
+-- RUTA: coviar-backend/scripts/018_ubicacion_bodega.sql
+-- Ubicación estructurada de la bodega: provincia, departamento y distrito se validan
+-- contra el catálogo geográfico del backend y se guardan con sus nombres, para que los
+-- reportes agrupen por provincia y departamento sin variantes de escritura.
+
+-- Variantes habituales de las provincias cargadas como texto libre
+UPDATE public.bodega SET provincia = 'Ciudad Autónoma de Buenos Aires'
+WHERE lower(trim(provincia)) IN ('caba', 'capital federal', 'ciudad de buenos aires');
+
+UPDATE public.bodega SET provincia = 'Tierra del Fuego'
+WHERE lower(trim(provincia)) LIKE 'tierra del fuego%';
+
+CREATE INDEX IF NOT EXISTS bodega_provincia_departamento
+  ON public.bodega (provincia, departamento);
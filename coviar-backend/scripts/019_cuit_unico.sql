@@ -0,0 +1,18 @@
+-- RUTA: coviar-backend/scripts/019_cuit_unico.sql
+-- El CUIT es la identidad legal de la bodega: no puede repetirse. El servicio valida el
+-- dígito verificador y rechaza duplicados; el índice único cubre las altas simultáneas.
+
+-- Si hay CUITs repetidos el índice no se crea; se listan para resolverlos a mano
+DO $$
+DECLARE
+  repetidos TEXT;
+BEGIN
+  SELECT string_agg(cuit::TEXT, ', ') INTO repetidos
+  FROM (SELECT cuit FROM public.bodega GROUP BY cuit HAVING COUNT(*) > 1) AS d;
+
+  IF repetidos IS NULL THEN
+    CREATE UNIQUE INDEX IF NOT EXISTS bodega_cuit_unico ON public.bodega (cuit);
+  ELSE
+    RAISE WARNING 'CUITs repetidos en bodega, no se crea el índice único: %', repetidos;
+  END IF;
+END $$;
@@ -0,0 +1,16 @@
+-- RUTA: coviar-backend/scripts/020_registro_inv.sql
+-- Registro de establecimientos inscriptos en el Instituto Nacional de Vitivinicultura.
+-- Un administrador lo importa desde un CSV (cada importación reemplaza la anterior) y
+-- contra él se verifican los números de INV de bodega y de viñedo de cada bodega.
+CREATE TABLE IF NOT EXISTS public.registro_inv (
+  "idRegistro" SERIAL PRIMARY KEY,
+  numero INTEGER NOT NULL CHECK (numero > 0),
+  tipo TEXT NOT NULL CHECK (tipo IN ('bodega', 'viñedo')),
+  cuit BIGINT NOT NULL,
+  razon_social TEXT NOT NULL DEFAULT '',
+  provincia TEXT,
+  fecha_importacion TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (numero, tipo)
+);
+
+CREATE INDEX IF NOT EXISTS idx_registro_inv_cuit ON public.registro_inv (cuit);
@@ -0,0 +1,24 @@
+-- RUTA: coviar-backend/scripts/021_busqueda_bodegas.sql
+-- Búsqueda de bodegas paginada por cursor: el orden se desempata por "idBodega", así
+-- que cada campo de orden necesita un índice compuesto, y la fecha de alta no puede ser
+-- nula para comparar cursores.
+UPDATE public.bodega SET created_at = NOW() WHERE created_at IS NULL;
+ALTER TABLE public.bodega ALTER COLUMN created_at SET DEFAULT NOW();
+ALTER TABLE public.bodega ALTER COLUMN created_at SET NOT NULL;
+
+UPDATE public.bodega SET razon_social = '' WHERE razon_social IS NULL;
+ALTER TABLE public.bodega ALTER COLUMN razon_social SET DEFAULT '';
+ALTER TABLE public.bodega ALTER COLUMN razon_social SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS bodega_orden_nombre ON public.bodega (nombre, "idBodega");
+CREATE INDEX IF NOT EXISTS bodega_orden_razon_social ON public.bodega (razon_social, "idBodega");
+CREATE INDEX IF NOT EXISTS bodega_orden_created_at ON public.bodega (created_at, "idBodega");
+
+-- Búsqueda por parte del nombre (ILIKE '%texto%') con índices de trigramas
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS bodega_nombre_trgm ON public.bodega USING gin (nombre gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS bodega_razon_social_trgm ON public.bodega USING gin (razon_social gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS bodega_nombre_fantasia_trgm ON public.bodega USING gin (nombre_fantasia gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_evaluacion_bodega_segmento ON public.evaluacion ("idBodega", "idSegmento");
+CREATE INDEX IF NOT EXISTS idx_certificado_bodega ON public.certificado ("idBodega", fecha_vencimiento);
@@ -0,0 +1,34 @@
+-- RUTA: coviar-backend/scripts/022_importacion_inv.sql
+-- Importaciones del registro del INV. El registro completo llega en una sola inserción
+-- y el trigger reemplaza registro_inv dentro de la misma transacción: si algo falla no
+-- se borra el registro anterior ni queda uno a medio cargar.
+CREATE TABLE IF NOT EXISTS public.importacion_inv (
+  "idImportacion" SERIAL PRIMARY KEY,
+  cantidad INTEGER NOT NULL CHECK (cantidad > 0),
+  fecha TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  registros JSONB -- se vacía al aplicar la importación
+);
+
+CREATE OR REPLACE FUNCTION public.aplicar_importacion_inv()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM public.registro_inv WHERE true;
+
+  INSERT INTO public.registro_inv (numero, tipo, cuit, razon_social, provincia, fecha_importacion)
+  SELECT numero, tipo, cuit, COALESCE(razon_social, ''), provincia, NEW.fecha
+  FROM jsonb_to_recordset(NEW.registros)
+    AS r(numero INTEGER, tipo TEXT, cuit BIGINT, razon_social TEXT, provincia TEXT);
+
+  IF (SELECT COUNT(*) FROM public.registro_inv) <> NEW.cantidad THEN
+    RAISE EXCEPTION 'la importación del registro del INV no cargó % establecimientos', NEW.cantidad;
+  END IF;
+
+  NEW.registros := NULL;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS aplicar_importacion_inv ON public.importacion_inv;
+CREATE TRIGGER aplicar_importacion_inv
+  BEFORE INSERT ON public.importacion_inv
+  FOR EACH ROW EXECUTE FUNCTION public.aplicar_importacion_inv();
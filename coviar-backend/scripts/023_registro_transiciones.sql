@@ -0,0 +1,34 @@
+-- RUTA: coviar-backend/scripts/023_registro_transiciones.sql
+-- Cada cambio de estado de una evaluación queda en transicion_evaluacion dentro de la
+-- misma actualización: quien cambia el estado indica en estas columnas quién lo hace (y
+-- un comentario opcional) y el trigger arma el registro. Si el registro falla, el estado
+-- no cambia.
+ALTER TABLE public.evaluacion
+  ADD COLUMN IF NOT EXISTS "transicion_idUsuario" INTEGER REFERENCES public.usuario("idUsuario"),
+  ADD COLUMN IF NOT EXISTS transicion_rol TEXT,
+  ADD COLUMN IF NOT EXISTS transicion_comentario TEXT;
+
+CREATE OR REPLACE FUNCTION public.registrar_transicion_evaluacion()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NEW.transicion_rol IS NULL THEN
+    RAISE EXCEPTION 'el cambio de estado de la evaluación % no indica quién lo hizo', NEW."idEvaluacion";
+  END IF;
+
+  INSERT INTO public.transicion_evaluacion ("idEvaluacion", estado_anterior, estado_nuevo, "idUsuario", rol, comentario, fecha)
+  VALUES (NEW."idEvaluacion", OLD.estado, NEW.estado, NEW."transicion_idUsuario", NEW.transicion_rol, NEW.transicion_comentario, NOW());
+
+  -- Se vacían para que el próximo cambio no herede el autor de este
+  NEW."transicion_idUsuario" := NULL;
+  NEW.transicion_rol := NULL;
+  NEW.transicion_comentario := NULL;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS registrar_transicion_evaluacion ON public.evaluacion;
+CREATE TRIGGER registrar_transicion_evaluacion
+  BEFORE UPDATE OF estado ON public.evaluacion
+  FOR EACH ROW
+  WHEN (OLD.estado IS DISTINCT FROM NEW.estado)
+  EXECUTE FUNCTION public.registrar_transicion_evaluacion();
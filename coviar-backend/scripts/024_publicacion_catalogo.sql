@@ -0,0 +1,29 @@
+-- RUTA: coviar-backend/scripts/024_publicacion_catalogo.sql
+-- Publicar o retirar una versión del catálogo es una sola actualización de su estado: el
+-- trigger retira la versión publicada anterior y actualiza la vigencia de los indicadores
+-- dentro de la misma transacción, así que nunca queda el catálogo sin versión publicada a
+-- medio camino.
+CREATE OR REPLACE FUNCTION public.aplicar_estado_version_catalogo()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NEW.estado = 'publicada' THEN
+    -- Al retirarla se vuelve a disparar este trigger, que deja sus indicadores sin vigencia
+    UPDATE public.version_catalogo
+    SET estado = 'retirada', fecha_retiro = NOW()
+    WHERE estado = 'publicada' AND "idVersion" <> NEW."idVersion";
+  END IF;
+
+  UPDATE public.indicador
+  SET vigente = (NEW.estado = 'publicada')
+  WHERE "idCapitulo" IN (SELECT "idCapitulo" FROM public.capitulo WHERE "idVersion" = NEW."idVersion");
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS aplicar_estado_version_catalogo ON public.version_catalogo;
+CREATE TRIGGER aplicar_estado_version_catalogo
+  BEFORE UPDATE OF estado ON public.version_catalogo
+  FOR EACH ROW
+  WHEN (OLD.estado IS DISTINCT FROM NEW.estado)
+  EXECUTE FUNCTION public.aplicar_estado_version_catalogo();
@@ -0,0 +1,28 @@
+-- RUTA: coviar-backend/scripts/025_evaluacion_en_curso.sql
+-- Cada bodega tiene a lo sumo una evaluación en curso (borrador, enviada, en revisión o
+-- reabierta). El índice evita que dos renovaciones o altas simultáneas creen dos
+-- borradores; la API responde 409 cuando lo viola.
+
+-- Si ya hay bodegas con más de una evaluación en curso hay que resolverlas a mano antes
+-- de crear el índice
+DO $$
+DECLARE
+  duplicadas TEXT;
+BEGIN
+  SELECT string_agg(DISTINCT "idBodega"::TEXT, ', ') INTO duplicadas
+  FROM (
+    SELECT "idBodega"
+    FROM public.evaluacion
+    WHERE estado IN ('borrador', 'enviada', 'en_revision', 'reabierta')
+    GROUP BY "idBodega"
+    HAVING COUNT(*) > 1
+  ) d;
+
+  IF duplicadas IS NOT NULL THEN
+    RAISE EXCEPTION 'bodegas con más de una evaluación en curso: %', duplicadas;
+  END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS evaluacion_una_en_curso
+  ON public.evaluacion ("idBodega")
+  WHERE estado IN ('borrador', 'enviada', 'en_revision', 'reabierta');